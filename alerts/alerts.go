@@ -0,0 +1,72 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Jenis peringatan
+const (
+	TypeStockLow = "stock_low"
+)
+
+// Alert adalah peringatan untuk admin
+type Alert struct {
+	Type   string      `json:"type"`
+	Subjek string      `json:"subjek"` // Ringkasan satu baris, dipakai sebagai subjek email
+	Pesan  string      `json:"pesan"`
+	Data   interface{} `json:"data"`
+	Waktu  time.Time   `json:"waktu"`
+}
+
+// StockLow adalah data peringatan untuk komik yang stoknya di bawah reorder level
+type StockLow struct {
+	KomikID      uint   `json:"komik_id"`
+	Nama         string `json:"nama"`
+	Stok         int    `json:"stok"`
+	ReorderLevel int    `json:"reorder_level"`
+}
+
+// NewStockLow membuat peringatan stok rendah
+func NewStockLow(data StockLow, waktu time.Time) Alert {
+	return Alert{
+		Type:   TypeStockLow,
+		Subjek: fmt.Sprintf("Stok komik %q hampir habis", data.Nama),
+		Pesan: fmt.Sprintf("Stok komik %q (ID %d) tinggal %d, di bawah reorder level %d. Segera lakukan restock.",
+			data.Nama, data.KomikID, data.Stok, data.ReorderLevel),
+		Data:  data,
+		Waktu: waktu,
+	}
+}
+
+// Notifier mengirim peringatan ke admin
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// LogNotifier menulis peringatan ke log server
+type LogNotifier struct{}
+
+// Notify menulis peringatan ke log
+func (LogNotifier) Notify(ctx context.Context, alert Alert) error {
+	slog.WarnContext(ctx, alert.Pesan, "alert", alert.Type)
+	return nil
+}
+
+// Multi mengirim peringatan ke beberapa notifier sekaligus. Kegagalan satu notifier
+// tidak menghentikan pengiriman ke notifier lain
+type Multi []Notifier
+
+// Notify mengirim peringatan ke semua notifier dan menggabungkan error-nya
+func (m Multi) Notify(ctx context.Context, alert Alert) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
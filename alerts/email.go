@@ -0,0 +1,48 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// EmailNotifier mengirim peringatan lewat email menggunakan server SMTP
+type EmailNotifier struct {
+	Host     string
+	Port     string
+	Username string // Kosongkan jika server SMTP tidak memerlukan login
+	Password string
+	From     string
+	To       []string
+}
+
+// Notify mengirim peringatan sebagai email teks biasa ke semua penerima
+func (n *EmailNotifier) Notify(ctx context.Context, alert Alert) error {
+	if len(n.To) == 0 {
+		return errors.New("penerima email peringatan belum diatur")
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", alert.Subjek))
+	fmt.Fprintf(&msg, "Date: %s\r\n", alert.Waktu.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(alert.Pesan)
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+	if err := smtp.SendMail(net.JoinHostPort(n.Host, n.Port), auth, n.From, n.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("gagal mengirim email peringatan: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"backend/alerts"
+	"backend/config"
+	"backend/controllers"
+	"backend/isbn"
+	"backend/jobs"
+	"backend/media"
+	"backend/metrics"
+	"backend/middlewares"
+	"backend/repository"
+	"backend/services"
+	"backend/websocket"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// App adalah satu instance aplikasi beserta semua dependensinya. Pengaturan, database,
+// storage, klien WebSocket dan metrics dimiliki setiap App, sehingga beberapa App dapat
+// berjalan dalam satu proses selama masing-masing memakai registry metrics sendiri. Tracing
+// tetap dikirim bersama oleh seluruh proses
+type App struct {
+	Database *config.Database
+	Storage  media.Storage
+	Metadata isbn.MetadataProvider // nil jika pencarian data buku dinonaktifkan
+	Notifier alerts.Notifier
+	Hub      *websocket.Hub
+	Handlers *controllers.Handlers
+	Metrics  *metrics.Metrics
+
+	config Config
+	auth   *middlewares.Auth // Validasi token JWT pada route
+
+	migrated     atomic.Bool // Migrasi database sudah selesai
+	shuttingDown atomic.Bool // Server sedang berhenti dan tidak menerima request baru
+
+	jobs     sync.WaitGroup
+	stopJobs context.CancelFunc
+}
+
+// New membuat App beserta repository, service dan handler yang memakai pengaturan cfg,
+// database, storage, provider data buku dan notifier yang diberikan. Metrics App dan statistik
+// pool database didaftarkan ke reg
+func New(cfg Config, database *config.Database, storage media.Storage, metadata isbn.MetadataProvider, notifier alerts.Notifier, reg prometheus.Registerer) *App {
+	m, err := metrics.New(reg)
+	if err != nil {
+		slog.Warn("Gagal mendaftarkan metrics", "error", err)
+	}
+	a := &App{
+		Database: database,
+		Storage:  storage,
+		Metadata: metadata,
+		Notifier: notifier,
+		Hub:      websocket.NewHub(m),
+		Metrics:  m,
+		config:   cfg,
+		auth:     middlewares.NewAuth(cfg.JWTSecret),
+	}
+
+	if err := database.RegisterMetrics(reg); err != nil {
+		slog.Warn("Gagal mencatat statistik pool database", "error", err)
+	}
+
+	db, readDB := database.DB, database.ReadDB
+	// URL cover diisi setiap kali komik dibaca. Plugin yang sudah terpasang oleh App lain
+	// pada database yang sama tidak dipasang ulang
+	if err := db.Use(media.GormPlugin{}); err != nil && !errors.Is(err, gorm.ErrRegistered) {
+		slog.Warn("Gagal memasang plugin cover", "error", err)
+	}
+	komiks := repository.NewKomikRepository(db, readDB)
+	comments := repository.NewCommentRepository(db, readDB)
+	users := repository.NewUserRepository(db)
+
+	a.Handlers = &controllers.Handlers{
+		Auth:         controllers.NewAuthHandler(services.NewAuthService(users, cfg.JWTSecret), m),
+		Komik:        controllers.NewKomikHandler(services.NewKomikService(komiks, storage)),
+		Comment:      controllers.NewCommentHandler(services.NewCommentService(comments, komiks)),
+		Cover:        controllers.NewCoverHandler(db, readDB, storage),
+		ISBN:         controllers.NewISBNHandler(komiks, metadata),
+		WebSocket:    controllers.NewWebSocketHandler(db, a.Hub),
+		Catalog:      controllers.NewCatalogHandler(db, readDB),
+		Series:       controllers.NewSeriesHandler(db, readDB),
+		Pricing:      controllers.NewPricingHandler(db, readDB),
+		Inventory:    controllers.NewInventoryHandler(db, readDB),
+		Bulk:         controllers.NewBulkHandler(db, readDB),
+		Webhook:      controllers.NewWebhookHandler(db, readDB),
+		Audit:        controllers.NewAuditHandler(db, readDB),
+		Reservation:  controllers.NewReservationHandler(db, readDB, cfg.ReservationTTL),
+		Wishlist:     controllers.NewWishlistHandler(db, readDB),
+		Notification: controllers.NewNotificationHandler(db, readDB),
+		Health:       controllers.NewHealthHandler(a),
+	}
+	return a
+}
+
+// Migrate menyesuaikan struktur database dengan model. Readiness check baru berhasil
+// setelah migrasi selesai
+func (a *App) Migrate() error {
+	if err := config.MigrateDatabase(a.Database.DB); err != nil {
+		return err
+	}
+	a.migrated.Store(true)
+	return nil
+}
+
+// Ping memastikan koneksi ke database utama masih dapat dipakai
+func (a *App) Ping(ctx context.Context) error {
+	return a.Database.Ping(ctx)
+}
+
+// Migrated melaporkan apakah Migrate sudah selesai
+func (a *App) Migrated() bool {
+	return a.migrated.Load()
+}
+
+// ShuttingDown melaporkan apakah Drain sudah dipanggil
+func (a *App) ShuttingDown() bool {
+	return a.shuttingDown.Load()
+}
+
+// StartJobs menjalankan pekerjaan latar belakang sampai Close dipanggil. Query dan perubahan
+// stok di dalamnya dicatat ke Metrics App ini
+func (a *App) StartJobs() {
+	ctx, cancel := context.WithCancel(metrics.NewContext(context.Background(), a.Metrics))
+	a.stopJobs = cancel
+	db := a.Database.DB
+	cfg := a.config
+
+	// Pemeriksaan stok rendah di latar belakang
+	a.runJob(ctx, jobs.NewLowStockChecker(db, a.Notifier, cfg.LowStockInterval).Run)
+
+	// Pelepasan reservasi stok yang kedaluwarsa
+	a.runJob(ctx, jobs.NewReservationSweeper(db, cfg.ReservationSweepInterval).Run)
+
+	// Penerusan event webhook yang tersimpan di outbox ke antrean webhook
+	a.runJob(ctx, jobs.NewOutboxRelay(db, cfg.OutboxInterval, cfg.OutboxRetention).Run)
+
+	// Pengiriman event realtime yang tersimpan di outbox ke klien WebSocket App ini
+	a.runJob(ctx, jobs.NewRealtimeRelay(db, a.Hub, cfg.OutboxInterval, cfg.OutboxGapTimeout).Run)
+
+	// Pengiriman event ke webhook beserta percobaan ulangnya
+	a.runJob(ctx, jobs.NewWebhookDispatcher(db, cfg.WebhookSender, cfg.WebhookInterval, cfg.WebhookMaxAttempts).Run)
+
+	// Pemeriksaan replika database agar query baca tidak diarahkan ke replika yang mati
+	a.runJob(ctx, func(ctx context.Context) {
+		a.Database.MonitorReplicas(ctx, cfg.ReplicaCheckInterval)
+	})
+}
+
+func (a *App) runJob(ctx context.Context, run func(context.Context)) {
+	a.jobs.Add(1)
+	go func() {
+		defer a.jobs.Done()
+		run(ctx)
+	}()
+}
+
+// Drain dipanggil saat server mulai berhenti: readiness check gagal agar load balancer
+// berhenti mengirim request baru, lalu klien WebSocket menerima close frame dan koneksi baru
+// ditolak. Drain menunggu sampai semua koneksi WebSocket tertutup atau ctx berakhir
+func (a *App) Drain(ctx context.Context) error {
+	a.shuttingDown.Store(true)
+	return a.Hub.Close(ctx)
+}
+
+// Close menghentikan pekerjaan latar belakang setelah putaran yang sedang berjalan selesai,
+// lalu menutup koneksi database. Dipanggil setelah server HTTP berhenti
+func (a *App) Close() error {
+	if a.stopJobs != nil {
+		a.stopJobs()
+	}
+	a.jobs.Wait()
+	return a.Database.Close()
+}
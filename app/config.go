@@ -0,0 +1,44 @@
+package app
+
+import (
+	"time"
+
+	"backend/config"
+	"backend/webhook"
+)
+
+// Config berisi pengaturan satu App. Setiap App memakai Config sendiri sehingga beberapa App
+// dalam satu proses dapat diatur berbeda
+type Config struct {
+	JWTSecret      []byte   // Kunci untuk menandatangani dan memvalidasi token JWT
+	TrustedProxies []string // IP atau CIDR proxy yang boleh mengirim X-Forwarded-For
+	ReservationTTL time.Duration
+
+	LowStockInterval         time.Duration
+	ReservationSweepInterval time.Duration
+	OutboxInterval           time.Duration
+	OutboxGapTimeout         time.Duration
+	OutboxRetention          time.Duration
+	WebhookSender            *webhook.Sender
+	WebhookInterval          time.Duration
+	WebhookMaxAttempts       int
+	ReplicaCheckInterval     time.Duration
+}
+
+// ConfigFromEnv membaca Config dari environment variable (lihat paket config)
+func ConfigFromEnv() Config {
+	return Config{
+		JWTSecret:                config.JWTSecret(),
+		TrustedProxies:           config.TrustedProxies(),
+		ReservationTTL:           config.ReservationTTL(),
+		LowStockInterval:         config.LowStockInterval(),
+		ReservationSweepInterval: config.ReservationSweepInterval(),
+		OutboxInterval:           config.OutboxInterval(),
+		OutboxGapTimeout:         config.OutboxGapTimeout(),
+		OutboxRetention:          config.OutboxRetention(),
+		WebhookSender:            config.WebhookSender(),
+		WebhookInterval:          config.WebhookInterval(),
+		WebhookMaxAttempts:       config.WebhookMaxAttempts(),
+		ReplicaCheckInterval:     config.ReplicaCheckInterval(),
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"time"
+
+	"backend/logging"
+	"backend/middlewares"
+	"backend/routes"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// Router membuat router HTTP dengan middleware, semua route aplikasi dan Swagger UI
+func (a *App) Router() *gin.Engine {
+	// Membuat instance Gin
+	router := gin.New()
+
+	// Nonaktifkan redirect trailing slash
+	router.RedirectTrailingSlash = false
+
+	// IP client (untuk audit log dan log request) hanya diambil dari X-Forwarded-For jika
+	// request datang dari proxy yang dipercaya
+	if err := router.SetTrustedProxies(a.config.TrustedProxies); err != nil {
+		logging.Fatal("Gagal mengatur trusted proxy", "error", err)
+	}
+
+	// Middleware CORS
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     []string{"http://localhost:5173", "https://uasfrontend-nine.vercel.app", "https://uas-frontend-qt2c.vercel.app", "https://uas-frontend-final.vercel.app", "https://uas-frontend-6l29.vercel.app"}, // URL frontend
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},                                                                                                                                                 // Metode HTTP yang diizinkan
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match", "X-Request-ID", "traceparent", "tracestate"},                                                                            // Header yang diizinkan
+		AllowCredentials: true,                                                                                                                                                                                              // Jika menggunakan cookie atau header Authorization
+		ExposeHeaders:    []string{"Content-Length", "ETag", "X-Request-ID"},                                                                                                                                                // Header yang dapat diakses oleh client
+		MaxAge:           12 * time.Hour,                                                                                                                                                                                    // Cache header selama 12 jam
+	}))
+
+	// ID request untuk audit log dan penelusuran, span tracing, log setiap request dan penanganan panic
+	router.Use(middlewares.RequestID(), middlewares.Tracing(), middlewares.Logger(), middlewares.Metrics(a.Metrics), middlewares.Recovery())
+
+	// Registrasi routes
+	a.RegisterRoutes(router)
+
+	// Tambahkan Swagger UI
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	return router
+}
+
+// RegisterRoutes mendaftarkan semua route aplikasi ke router tanpa middleware tambahan
+func (a *App) RegisterRoutes(router *gin.Engine) {
+	routes.RegisterHealthRoutes(router, a.Handlers)
+	routes.RegisterRoutes(router, a.Handlers, a.auth)
+	routes.RegisterCommentRoutes(router, a.Handlers, a.auth) // Aktifkan rute komentar
+	routes.RegisterCatalogRoutes(router, a.Handlers, a.auth)
+	routes.RegisterSeriesRoutes(router, a.Handlers, a.auth)
+	routes.RegisterPricingRoutes(router, a.Handlers, a.auth)
+	routes.RegisterAdminRoutes(router, a.Handlers, a.auth)
+	routes.RegisterReservationRoutes(router, a.Handlers, a.auth)
+	routes.RegisterWishlistRoutes(router, a.Handlers, a.auth)
+	routes.RegisterNotificationRoutes(router, a.Handlers, a.auth)
+}
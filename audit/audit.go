@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// abaikan berisi field yang selalu berubah sehingga tidak dicatat sebagai perubahan
+var abaikan = map[string]bool{"updated_at": true}
+
+// Entry adalah aksi admin yang akan dicatat
+type Entry struct {
+	UserID    *uint
+	Aksi      string // Salah satu models.AuditCreate, models.AuditUpdate dan seterusnya
+	Entitas   string
+	EntitasID uint
+	IP        string
+	RequestID string
+}
+
+// Change adalah nilai satu field sebelum dan sesudah aksi. Sebelum kosong untuk data yang
+// baru dibuat dan Sesudah kosong untuk data yang dihapus
+type Change struct {
+	Sebelum json.RawMessage `json:"sebelum,omitempty"`
+	Sesudah json.RawMessage `json:"sesudah,omitempty"`
+}
+
+// Snapshot menyimpan keadaan data saat ini sebagai JSON. Dipakai untuk mengambil nilai
+// sebelum data diubah, karena data yang sama biasanya diubah di tempat
+func Snapshot(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// Diff membandingkan field tingkat atas dari representasi JSON sebelum dan sesudah aksi.
+// Nilai nil berarti data belum ada (create) atau sudah tidak ada (delete)
+func Diff(before, after interface{}) (map[string]Change, error) {
+	sebelum, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	sesudah, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(sebelum)+len(sesudah))
+	for key := range sebelum {
+		keys = append(keys, key)
+	}
+	for key := range sesudah {
+		if _, ok := sebelum[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	diff := map[string]Change{}
+	for _, key := range keys {
+		if abaikan[key] || bytes.Equal(sebelum[key], sesudah[key]) {
+			continue
+		}
+		diff[key] = Change{Sebelum: sebelum[key], Sesudah: sesudah[key]}
+	}
+	return diff, nil
+}
+
+// Log menyimpan audit log beserta perubahan antara before dan after. Harus dipanggil di
+// dalam transaksi yang sama dengan aksinya agar log hanya ada jika aksinya tersimpan
+func Log(tx *gorm.DB, entry Entry, before, after interface{}) error {
+	diff, err := Diff(before, after)
+	if err != nil {
+		return err
+	}
+	perubahan, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.AuditLog{
+		UserID:    entry.UserID,
+		Aksi:      entry.Aksi,
+		Entitas:   entry.Entitas,
+		EntitasID: entry.EntitasID,
+		Perubahan: perubahan,
+		IP:        entry.IP,
+		RequestID: entry.RequestID,
+	}).Error
+}
+
+// fields mengubah data menjadi map field JSON. Data nil menghasilkan map kosong
+func fields(v interface{}) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return map[string]json.RawMessage{}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = map[string]json.RawMessage{}
+	}
+	return m, nil
+}
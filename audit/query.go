@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ukuranBatch adalah jumlah audit log yang dibaca dari database sekaligus saat ekspor
+const ukuranBatch = 500
+
+// KolomEkspor adalah kolom file ekspor CSV
+var KolomEkspor = []string{"id", "created_at", "user_id", "aksi", "entitas", "entitas_id", "perubahan", "ip", "request_id"}
+
+// Filter membatasi audit log yang ditampilkan atau diekspor. Field kosong tidak dipakai
+type Filter struct {
+	UserID    *uint
+	Aksi      string
+	Entitas   string
+	EntitasID *uint
+	RequestID string
+	Dari      *time.Time // Inklusif
+	Sampai    *time.Time // Eksklusif
+}
+
+// Apply menambahkan kondisi filter ke query audit log
+func (f Filter) Apply(db *gorm.DB) *gorm.DB {
+	db = db.Model(&models.AuditLog{})
+	if f.UserID != nil {
+		db = db.Where("user_id = ?", *f.UserID)
+	}
+	if f.Aksi != "" {
+		db = db.Where("aksi = ?", f.Aksi)
+	}
+	if f.Entitas != "" {
+		db = db.Where("entitas = ?", f.Entitas)
+	}
+	if f.EntitasID != nil {
+		db = db.Where("entitas_id = ?", *f.EntitasID)
+	}
+	if f.RequestID != "" {
+		db = db.Where("request_id = ?", f.RequestID)
+	}
+	if f.Dari != nil {
+		db = db.Where("created_at >= ?", *f.Dari)
+	}
+	if f.Sampai != nil {
+		db = db.Where("created_at < ?", *f.Sampai)
+	}
+	return db
+}
+
+// ExportCSV menulis audit log yang sesuai filter sebagai CSV, diurutkan dari yang terlama
+func ExportCSV(db *gorm.DB, f Filter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(KolomEkspor); err != nil {
+		return err
+	}
+	err := eachBatch(db, f, func(batch []models.AuditLog) error {
+		for _, item := range batch {
+			if err := writer.Write(values(item)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportJSON menulis audit log yang sesuai filter sebagai array JSON, diurutkan dari yang terlama
+func ExportJSON(db *gorm.DB, f Filter, w io.Writer) error {
+	separator := "[\n"
+	err := eachBatch(db, f, func(batch []models.AuditLog) error {
+		for _, item := range batch {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			separator = ",\n"
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if separator == "[\n" {
+		_, err = io.WriteString(w, "[]\n")
+	} else {
+		_, err = io.WriteString(w, "\n]\n")
+	}
+	return err
+}
+
+func eachBatch(db *gorm.DB, f Filter, fn func([]models.AuditLog) error) error {
+	var batch []models.AuditLog
+	return f.Apply(db).Order("id").FindInBatches(&batch, ukuranBatch, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+func values(item models.AuditLog) []string {
+	userID := ""
+	if item.UserID != nil {
+		userID = strconv.FormatUint(uint64(*item.UserID), 10)
+	}
+	return []string{
+		strconv.FormatUint(uint64(item.ID), 10),
+		item.CreatedAt.Format(time.RFC3339),
+		userID,
+		item.Aksi,
+		item.Entitas,
+		strconv.FormatUint(uint64(item.EntitasID), 10),
+		string(item.Perubahan),
+		item.IP,
+		item.RequestID,
+	}
+}
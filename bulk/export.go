@@ -0,0 +1,145 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ukuranBatch adalah jumlah komik yang dibaca dari database sekaligus saat ekspor
+const ukuranBatch = 500
+
+// KolomEkspor adalah kolom file ekspor CSV dan XLSX
+var KolomEkspor = []string{
+	"id", "nama", "isbn10", "isbn13", "author", "genre", "tahun_terbit", "publisher", "stok", "reorder_level", "harga", "mata_uang",
+	"created_at", "updated_at",
+}
+
+// ExportCSV menulis seluruh katalog komik sebagai CSV
+func ExportCSV(db *gorm.DB, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(KolomEkspor); err != nil {
+		return err
+	}
+	err := eachBatch(db, func(batch []models.Komik) error {
+		for _, komik := range batch {
+			if err := writer.Write(values(komik)); err != nil {
+				return err
+			}
+		}
+		// Kirim setiap batch ke client tanpa menunggu seluruh data selesai dibaca
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportJSON menulis seluruh katalog komik sebagai array JSON
+func ExportJSON(db *gorm.DB, w io.Writer) error {
+	separator := "[\n"
+	err := eachBatch(db, func(batch []models.Komik) error {
+		for _, komik := range batch {
+			data, err := json.Marshal(komik)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			separator = ",\n"
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if separator == "[\n" {
+		_, err = io.WriteString(w, "[]\n")
+	} else {
+		_, err = io.WriteString(w, "\n]\n")
+	}
+	return err
+}
+
+// ExportXLSX menulis seluruh katalog komik sebagai file Excel. Seperti CSV, setiap batch
+// langsung dikirim ke client sehingga memori tidak bertambah sebanding jumlah komik.
+// Tanggal ditulis sebagai teks RFC 3339
+func ExportXLSX(db *gorm.DB, w io.Writer) error {
+	writer, err := newXLSXWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteRow(toCells(KolomEkspor)...); err != nil {
+		return err
+	}
+	err = eachBatch(db, func(batch []models.Komik) error {
+		for _, komik := range batch {
+			if err := writer.WriteRow(
+				komik.ID, komik.Nama, stringValue(komik.ISBN10), stringValue(komik.ISBN13), komik.Author, komik.Genre, komik.TahunTerbit, komik.Publisher,
+				komik.Stok, komik.ReorderLevel, komik.Harga, komik.MataUang, komik.CreatedAt.Format(time.RFC3339), komik.UpdatedAt.Format(time.RFC3339),
+			); err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// eachBatch membaca komik secara bertahap berdasarkan ID
+func eachBatch(db *gorm.DB, fn func([]models.Komik) error) error {
+	var batch []models.Komik
+	return db.Preload("Genres").Order("id").FindInBatches(&batch, ukuranBatch, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+func values(komik models.Komik) []string {
+	return []string{
+		strconv.FormatUint(uint64(komik.ID), 10),
+		komik.Nama,
+		stringValue(komik.ISBN10),
+		stringValue(komik.ISBN13),
+		komik.Author,
+		komik.Genre,
+		strconv.Itoa(komik.TahunTerbit),
+		komik.Publisher,
+		strconv.Itoa(komik.Stok),
+		strconv.Itoa(komik.ReorderLevel),
+		strconv.FormatInt(komik.Harga, 10),
+		komik.MataUang,
+		komik.CreatedAt.Format(time.RFC3339),
+		komik.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toCells(values []string) []interface{} {
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	return cells
+}
@@ -0,0 +1,226 @@
+package bulk
+
+import (
+	"errors"
+	"strings"
+
+	"backend/catalog"
+	"backend/inventory"
+	"backend/isbn"
+	"backend/models"
+	"backend/notification"
+	"backend/outbox"
+	"backend/pricing"
+	"backend/repository"
+	"backend/validation"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Aksi yang dilakukan untuk setiap baris impor
+const (
+	AksiDibuat     = "dibuat"
+	AksiDiperbarui = "diperbarui"
+	AksiGagal      = "gagal"
+)
+
+// ErrAdaBarisGagal dikembalikan jika ada baris yang tidak valid. Tidak ada data yang disimpan
+var ErrAdaBarisGagal = errors.New("ada baris yang tidak valid, tidak ada data yang disimpan")
+
+// errDryRun dipakai untuk membatalkan transaksi pada mode dry run
+var errDryRun = errors.New("dry run")
+
+// Options mengatur jalannya impor
+type Options struct {
+	DryRun         bool   // Hanya memeriksa data tanpa menyimpan
+	AcceptLanguage string // Bahasa pesan error validasi
+	UserID         *uint  // Admin yang mengimpor, dicatat pada perubahan stok
+
+	// Audit dipanggil di dalam transaksi impor sebelum disimpan, misalnya untuk mencatat
+	// audit log. Tidak dipanggil jika ada baris yang gagal atau pada mode dry run
+	Audit func(tx *gorm.DB, report *Report) error
+}
+
+// HasilBaris adalah hasil impor satu baris
+type HasilBaris struct {
+	Baris   int               `json:"baris"`
+	Aksi    string            `json:"aksi"`
+	KomikID uint              `json:"komik_id,omitempty"`
+	Nama    string            `json:"nama"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// Report adalah ringkasan hasil impor
+type Report struct {
+	DryRun     bool         `json:"dry_run"`
+	Total      int          `json:"total"`
+	Dibuat     int          `json:"dibuat"`
+	Diperbarui int          `json:"diperbarui"`
+	Gagal      int          `json:"gagal"`
+	Baris      []HasilBaris `json:"baris"`
+}
+
+// Import menyimpan semua baris dalam satu transaksi. Komik dengan ISBN yang sama, atau jika
+// baris tidak memiliki ISBN yang cocok, dengan nama yang sama (tanpa membedakan huruf
+// besar/kecil) diperbarui, selain itu komik baru dibuat.
+// Jika ada baris yang gagal atau mode dry run dipakai, seluruh perubahan dibatalkan
+// dan report tetap dikembalikan
+func Import(db *gorm.DB, rows []Row, opts Options) (*Report, error) {
+	report := &Report{DryRun: opts.DryRun, Total: len(rows), Baris: []HasilBaris{}}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			hasil, err := importRow(tx, row, opts)
+			if err != nil {
+				return err
+			}
+			switch hasil.Aksi {
+			case AksiDibuat:
+				report.Dibuat++
+			case AksiDiperbarui:
+				report.Diperbarui++
+			default:
+				report.Gagal++
+			}
+			report.Baris = append(report.Baris, hasil)
+		}
+
+		if report.Gagal > 0 {
+			return ErrAdaBarisGagal
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		if opts.Audit != nil {
+			return opts.Audit(tx, report)
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	return report, err
+}
+
+// importRow menyimpan satu baris. Error yang dikembalikan adalah error database yang
+// menghentikan seluruh impor, sedangkan data yang tidak valid dicatat di HasilBaris
+func importRow(tx *gorm.DB, row Row, opts Options) (HasilBaris, error) {
+	hasil := HasilBaris{Baris: row.Line, Errors: row.Errors}
+	if row.Record.Nama != nil {
+		hasil.Nama = strings.TrimSpace(*row.Record.Nama)
+	}
+	if len(hasil.Errors) > 0 {
+		hasil.Aksi = AksiGagal
+		return hasil, nil
+	}
+	if hasil.Nama == "" {
+		hasil.Aksi = AksiGagal
+		hasil.Errors = map[string]string{"nama": "nama wajib diisi untuk mencocokkan komik"}
+		return hasil, nil
+	}
+
+	komik, previous, err := findKomik(tx, row.Record, hasil.Nama)
+	if err != nil {
+		return hasil, err
+	}
+	row.Record.Apply(&komik)
+	komik.Nama = strings.TrimSpace(komik.Nama)
+	komik.MataUang = pricing.MataUang(komik.MataUang)
+	komik.NormalizeISBN()
+
+	if err := validation.Validate(&komik); err != nil {
+		messages, ok := validation.Messages(err, opts.AcceptLanguage)
+		if !ok {
+			return hasil, err
+		}
+		hasil.Aksi = AksiGagal
+		hasil.Errors = messages
+		return hasil, nil
+	}
+	if err := catalog.CheckISBN(tx, &komik); err != nil {
+		if !errors.Is(err, catalog.ErrISBNDipakai) {
+			return hasil, err
+		}
+		hasil.Aksi = AksiGagal
+		hasil.Errors = map[string]string{"isbn": err.Error()}
+		return hasil, nil
+	}
+	if err := catalog.Resolve(tx, &komik, previous); err != nil {
+		return hasil, err
+	}
+
+	movement := models.StockMovement{
+		Alasan:      models.AlasanAdjustment,
+		UserID:      opts.UserID,
+		Referensi:   "impor",
+		StokSetelah: komik.Stok,
+	}
+	if previous == nil {
+		hasil.Aksi = AksiDibuat
+		komik.Version = 1
+		movement.Alasan = models.AlasanRestock
+		movement.Delta = komik.Stok
+		err = tx.Omit(clause.Associations).Create(&komik).Error
+	} else {
+		hasil.Aksi = AksiDiperbarui
+		movement.Delta = komik.Stok - previous.Stok
+		err = repository.UpdateVersioned(tx.Omit(clause.Associations), &komik, &komik.Version)
+	}
+	if errors.Is(err, repository.ErrVersionConflict) {
+		hasil.Aksi = AksiGagal
+		hasil.Errors = map[string]string{"version": "komik diubah oleh pengguna lain selama impor, ulangi impor"}
+		return hasil, nil
+	}
+	if err != nil {
+		return hasil, err
+	}
+	if err := catalog.SaveGenres(tx, &komik); err != nil {
+		return hasil, err
+	}
+	movement.KomikID = komik.ID
+	if err := inventory.Log(tx, &movement); err != nil {
+		return hasil, err
+	}
+	event := models.EventKomikUpdated
+	if previous == nil {
+		event = models.EventKomikCreated
+	}
+	if err := outbox.Webhook(tx, event, komik); err != nil {
+		return hasil, err
+	}
+	if inventory.BackInStock(&movement) {
+		if err := notification.NotifyBackInStock(tx, komik.ID); err != nil {
+			return hasil, err
+		}
+	}
+	hasil.KomikID = komik.ID
+	return hasil, nil
+}
+
+// findKomik mencari komik berdasarkan ISBN lalu nama. previous bernilai nil jika komik belum ada.
+// Komik yang ditemukan dikunci sampai impor selesai agar perubahan stok atau data dari request
+// lain tidak tertimpa dan selisih stok dihitung dari stok terbaru
+func findKomik(tx *gorm.DB, record Record, nama string) (komik models.Komik, previous *models.Komik, err error) {
+	tx = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Session(&gorm.Session{})
+	byName := tx.Where("LOWER(nama) = ?", strings.ToLower(nama))
+
+	isbn10, isbn13 := isbn.Complete(record.ISBN10, record.ISBN13)
+	if isbn10 != nil || isbn13 != nil {
+		result := tx.Where("isbn10 = ?", isbn10).Or("isbn13 = ?", isbn13).Limit(1).Find(&komik)
+		if result.Error != nil || result.RowsAffected > 0 {
+			existing := komik
+			return komik, &existing, result.Error
+		}
+		// Komik dengan nama sama tetapi ISBN berbeda dianggap edisi lain, hanya komik
+		// tanpa ISBN (misalnya data lama) yang dilengkapi ISBN-nya
+		byName = byName.Where("isbn10 IS NULL AND isbn13 IS NULL")
+	}
+
+	result := byName.Limit(1).Find(&komik)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return models.Komik{}, nil, result.Error
+	}
+	existing := komik
+	return komik, &existing, nil
+}
@@ -0,0 +1,195 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"backend/models"
+)
+
+// Kolom adalah kolom yang dapat diimpor. Kolom lain hasil ekspor (id, created_at,
+// updated_at, dll) diabaikan sehingga file ekspor dapat diimpor kembali
+var Kolom = []string{"nama", "isbn10", "isbn13", "author", "genre", "tahun_terbit", "publisher", "stok", "reorder_level", "harga", "mata_uang"}
+
+// Record adalah data satu komik pada file impor. Field yang tidak ada di file (atau sel CSV
+// yang kosong) bernilai nil dan tidak mengubah data komik yang sudah ada
+type Record struct {
+	Nama         *string `json:"nama"`
+	ISBN10       *string `json:"isbn10"`
+	ISBN13       *string `json:"isbn13"`
+	Author       *string `json:"author"`
+	Genre        *string `json:"genre"`
+	TahunTerbit  *int    `json:"tahun_terbit"`
+	Publisher    *string `json:"publisher"`
+	Stok         *int    `json:"stok"`
+	ReorderLevel *int    `json:"reorder_level"`
+	Harga        *int64  `json:"harga"`
+	MataUang     *string `json:"mata_uang"`
+}
+
+// Row adalah satu baris file impor beserta error yang ditemukan saat membacanya
+type Row struct {
+	Line   int
+	Record Record
+	Errors map[string]string
+}
+
+// Apply menyalin field yang diisi ke data komik
+func (r Record) Apply(komik *models.Komik) {
+	if r.Nama != nil {
+		komik.Nama = *r.Nama
+	}
+	if r.ISBN10 != nil {
+		komik.ISBN10 = r.ISBN10
+	}
+	if r.ISBN13 != nil {
+		komik.ISBN13 = r.ISBN13
+	}
+	if r.Author != nil {
+		komik.Author = *r.Author
+	}
+	if r.Genre != nil {
+		komik.Genre = *r.Genre
+	}
+	if r.TahunTerbit != nil {
+		komik.TahunTerbit = *r.TahunTerbit
+	}
+	if r.Publisher != nil {
+		komik.Publisher = *r.Publisher
+	}
+	if r.Stok != nil {
+		komik.Stok = *r.Stok
+	}
+	if r.ReorderLevel != nil {
+		komik.ReorderLevel = *r.ReorderLevel
+	}
+	if r.Harga != nil {
+		komik.Harga = *r.Harga
+	}
+	if r.MataUang != nil {
+		komik.MataUang = *r.MataUang
+	}
+}
+
+// ReadCSV membaca file CSV dengan baris pertama berisi nama kolom
+func ReadCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file CSV kosong")
+		}
+		return nil, err
+	}
+	for i := range header {
+		// Excel menambahkan BOM di awal file CSV UTF-8
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+	if !contains(header, "nama") {
+		return nil, errors.New("file CSV harus memiliki kolom nama")
+	}
+
+	var rows []Row
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line, Errors: map[string]string{}}
+		for i, column := range header {
+			row.setCSV(column, strings.TrimSpace(values[i]))
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ReadNDJSON membaca file NDJSON, satu objek JSON komik per baris. Baris kosong diabaikan
+func ReadNDJSON(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []Row
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		row := Row{Line: line, Errors: map[string]string{}}
+		if err := json.Unmarshal(data, &row.Record); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				row.Errors[typeErr.Field] = fmt.Sprintf("%s harus berupa %s", typeErr.Field, typeErr.Type)
+			} else {
+				row.Errors["baris"] = "JSON tidak valid: " + err.Error()
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// setCSV mengisi field record dari nilai kolom CSV. Sel kosong dianggap tidak diisi
+func (row *Row) setCSV(column, value string) {
+	if value == "" {
+		return
+	}
+	record := &row.Record
+	switch column {
+	case "nama":
+		record.Nama = &value
+	case "isbn10":
+		record.ISBN10 = &value
+	case "isbn13":
+		record.ISBN13 = &value
+	case "author":
+		record.Author = &value
+	case "genre":
+		record.Genre = &value
+	case "publisher":
+		record.Publisher = &value
+	case "mata_uang":
+		record.MataUang = &value
+	case "tahun_terbit", "stok", "reorder_level":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			row.Errors[column] = column + " harus berupa bilangan bulat"
+			return
+		}
+		switch column {
+		case "stok":
+			record.Stok = &n
+		case "reorder_level":
+			record.ReorderLevel = &n
+		default:
+			record.TahunTerbit = &n
+		}
+	case "harga":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			row.Errors[column] = column + " harus berupa bilangan bulat dalam satuan terkecil mata uang"
+			return
+		}
+		record.Harga = &n
+	}
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package bulk
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Bagian tetap dari file XLSX dengan satu sheet bernama Sheet1
+var xlsxParts = []struct{ nama, isi string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter menulis file Excel dengan satu sheet langsung ke writer tujuan. Setiap baris
+// langsung dikompresi ke isi sheet di dalam zip, sehingga workbook tidak pernah disimpan
+// utuh di memori atau file sementara dan file dapat dikirim ke client sedikit demi sedikit
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	baris int
+}
+
+// newXLSXWriter menulis bagian tetap file XLSX ke w lalu membuka isi sheet untuk WriteRow
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		fw, err := zw.Create(part.nama)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(fw, part.isi); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow menulis satu baris. Bilangan bulat ditulis sebagai angka dan nilai lainnya
+// sebagai teks
+func (x *xlsxWriter) WriteRow(values ...interface{}) error {
+	x.baris++
+	if _, err := fmt.Fprintf(x.sheet, `<row r="%d">`, x.baris); err != nil {
+		return err
+	}
+	for i, value := range values {
+		cell := kolomXLSX(i) + strconv.Itoa(x.baris)
+		var err error
+		switch v := value.(type) {
+		case int, int64, uint:
+			_, err = fmt.Fprintf(x.sheet, `<c r="%s"><v>%d</v></c>`, cell, v)
+		default:
+			if _, err = fmt.Fprintf(x.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, cell); err != nil {
+				return err
+			}
+			if err = xml.EscapeText(x.sheet, []byte(fmt.Sprint(v))); err != nil {
+				return err
+			}
+			_, err = io.WriteString(x.sheet, `</t></is></c>`)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(x.sheet, `</row>`)
+	return err
+}
+
+// Flush mengirim data zip yang sudah lengkap ke writer tujuan
+func (x *xlsxWriter) Flush() error {
+	return x.zip.Flush()
+}
+
+// Close menutup isi sheet dan menulis daftar isi zip
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// kolomXLSX mengembalikan nama kolom Excel (A, B, ..., Z, AA, ...) untuk indeks mulai dari 0
+func kolomXLSX(i int) string {
+	nama := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		nama = string(rune('A'+(i-1)%26)) + nama
+	}
+	return nama
+}
@@ -0,0 +1,168 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound dikembalikan jika author_id, publisher_id atau genre_ids tidak ditemukan
+var ErrNotFound = errors.New("data katalog tidak ditemukan")
+
+// Key menormalisasi nama author, publisher atau genre sehingga penulisan yang berbeda
+// seperti "Eiichiro Oda", "oda eiichiro" dan "Oda, Eiichiro" menghasilkan kunci yang sama
+func Key(nama string) string {
+	words := strings.FieldsFunc(strings.ToLower(nama), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// SplitGenres memecah teks genre seperti "Action, Adventure" menjadi daftar nama genre
+func SplitGenres(genre string) []string {
+	var names []string
+	for _, name := range strings.FieldsFunc(genre, func(r rune) bool { return r == ',' || r == ';' }) {
+		if name = strings.TrimSpace(name); Key(name) != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// JoinGenres menggabungkan nama genre menjadi teks yang disimpan di kolom genre komik
+func JoinGenres(genres []models.Genre) string {
+	names := make([]string, len(genres))
+	for i, genre := range genres {
+		names[i] = genre.Nama
+	}
+	return strings.Join(names, ", ")
+}
+
+// FirstOrCreate mencari entitas katalog dengan kunci yang sama dengan nama, atau membuatnya
+// jika belum ada. item harus berupa *models.Author, *models.Publisher atau *models.Genre
+func FirstOrCreate(tx *gorm.DB, item models.EntitasKatalog, nama string) error {
+	data := item.DataKatalog()
+	data.Nama = strings.TrimSpace(nama)
+	data.Kunci = Key(nama)
+	if data.Kunci == "" {
+		return fmt.Errorf("nama %q tidak valid", nama)
+	}
+	return tx.Where("kunci = ?", data.Kunci).FirstOrCreate(item).Error
+}
+
+// Resolve menghubungkan komik dengan Author, Publisher dan Genre. ID yang dikirim client
+// diutamakan, selain itu entitas dicari atau dibuat dari teks author, publisher dan genre.
+// previous adalah data komik sebelum diubah (nil saat komik baru atau diganti seluruhnya),
+// dipakai untuk mengetahui apakah client mengubah teks atau ID-nya. Kolom teks selalu
+// diisi ulang dengan nama dari katalog agar tetap kompatibel dengan client lama
+func Resolve(tx *gorm.DB, komik *models.Komik, previous *models.Komik) error {
+	var err error
+
+	var author models.Author
+	useAuthorID := previous == nil || !sameID(komik.AuthorID, previous.AuthorID) || komik.Author == previous.Author
+	if komik.AuthorID, komik.Author, err = resolveOne(tx, &author, komik.AuthorID, komik.Author, useAuthorID); err != nil {
+		return err
+	}
+
+	var publisher models.Publisher
+	usePublisherID := previous == nil || !sameID(komik.PublisherID, previous.PublisherID) || komik.Publisher == previous.Publisher
+	if komik.PublisherID, komik.Publisher, err = resolveOne(tx, &publisher, komik.PublisherID, komik.Publisher, usePublisherID); err != nil {
+		return err
+	}
+
+	switch {
+	case komik.GenreIDs != nil:
+		komik.Genres = []models.Genre{}
+		if len(komik.GenreIDs) > 0 {
+			if err := tx.Where("id IN ?", komik.GenreIDs).Order("nama").Find(&komik.Genres).Error; err != nil {
+				return err
+			}
+			if len(komik.Genres) != len(uniqueIDs(komik.GenreIDs)) {
+				return fmt.Errorf("%w: genre_ids", ErrNotFound)
+			}
+		}
+	case previous == nil || komik.Genre != previous.Genre:
+		komik.Genres = []models.Genre{}
+		seen := make(map[uint]bool)
+		for _, name := range SplitGenres(komik.Genre) {
+			var genre models.Genre
+			if err := FirstOrCreate(tx, &genre, name); err != nil {
+				return err
+			}
+			if !seen[genre.ID] {
+				seen[genre.ID] = true
+				komik.Genres = append(komik.Genres, genre)
+			}
+		}
+	default:
+		komik.Genres = []models.Genre{}
+		if err := tx.Model(komik).Order("nama").Association("Genres").Find(&komik.Genres); err != nil {
+			return err
+		}
+	}
+	komik.Genre = JoinGenres(komik.Genres)
+	komik.GenreIDs = nil
+	return nil
+}
+
+// SaveGenres menyimpan relasi komik dengan genre hasil Resolve
+func SaveGenres(tx *gorm.DB, komik *models.Komik) error {
+	return tx.Model(komik).Association("Genres").Replace(komik.Genres)
+}
+
+// resolveOne mengembalikan ID dan nama entitas katalog berdasarkan ID atau teks nama
+func resolveOne(tx *gorm.DB, item models.EntitasKatalog, id *uint, nama string, useID bool) (*uint, string, error) {
+	if id != nil && useID {
+		if err := tx.First(item, *id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, "", fmt.Errorf("%w: %s %d", ErrNotFound, column(item), *id)
+			}
+			return nil, "", err
+		}
+		data := item.DataKatalog()
+		return &data.ID, data.Nama, nil
+	}
+
+	if Key(nama) == "" {
+		return nil, "", nil
+	}
+	if err := FirstOrCreate(tx, item, nama); err != nil {
+		return nil, "", err
+	}
+	data := item.DataKatalog()
+	return &data.ID, data.Nama, nil
+}
+
+// column mengembalikan nama kolom relasi entitas katalog pada tabel komik
+func column(item models.EntitasKatalog) string {
+	switch item.(type) {
+	case *models.Author:
+		return "author_id"
+	case *models.Publisher:
+		return "publisher_id"
+	default:
+		return "genre_id"
+	}
+}
+
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func uniqueIDs(ids []uint) map[uint]bool {
+	unique := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		unique[id] = true
+	}
+	return unique
+}
@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrISBNDipakai dikembalikan jika ISBN komik sudah dipakai oleh komik lain
+var ErrISBNDipakai = errors.New("ISBN sudah dipakai oleh komik lain")
+
+// CheckISBN memastikan ISBN-10 dan ISBN-13 komik belum dipakai oleh komik lain.
+// ISBN harus sudah dinormalisasi dengan Komik.NormalizeISBN
+func CheckISBN(tx *gorm.DB, komik *models.Komik) error {
+	if komik.ISBN10 == nil && komik.ISBN13 == nil {
+		return nil
+	}
+
+	var other models.Komik
+	result := tx.Select("id").Where("id <> ?", komik.ID).
+		Where(tx.Where("isbn10 = ?", komik.ISBN10).Or("isbn13 = ?", komik.ISBN13)).
+		Limit(1).Find(&other)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return fmt.Errorf("%w (komik %d)", ErrISBNDipakai, other.ID)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package catalog
+
+import (
+	"log/slog"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// InUse memeriksa apakah entitas katalog masih dipakai oleh komik
+func InUse(tx *gorm.DB, item models.EntitasKatalog) (bool, error) {
+	var count int64
+	id := item.DataKatalog().ID
+
+	var err error
+	if _, ok := item.(*models.Genre); ok {
+		err = tx.Table("komik_genres").Where("genre_id = ?", id).Count(&count).Error
+	} else {
+		err = tx.Model(&models.Komik{}).Where(column(item)+" = ?", id).Count(&count).Error
+	}
+	return count > 0, err
+}
+
+// SyncNames menyalin nama baru entitas katalog ke kolom teks pada komik yang memakainya
+func SyncNames(tx *gorm.DB, item models.EntitasKatalog) error {
+	data := item.DataKatalog()
+
+	switch item.(type) {
+	case *models.Author:
+		return tx.Model(&models.Komik{}).Where("author_id = ?", data.ID).
+			Updates(map[string]interface{}{"author": data.Nama, "version": gorm.Expr("version + 1")}).Error
+	case *models.Publisher:
+		return tx.Model(&models.Komik{}).Where("publisher_id = ?", data.ID).
+			Updates(map[string]interface{}{"publisher": data.Nama, "version": gorm.Expr("version + 1")}).Error
+	}
+
+	var komik []models.Komik
+	err := tx.Preload("Genres", func(db *gorm.DB) *gorm.DB { return db.Order("nama") }).
+		Where("id IN (?)", tx.Table("komik_genres").Select("komik_id").Where("genre_id = ?", data.ID)).
+		Find(&komik).Error
+	if err != nil {
+		return err
+	}
+	for _, k := range komik {
+		err := tx.Model(&k).Updates(map[string]interface{}{"genre": JoinGenres(k.Genres), "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrateLegacy menghubungkan komik lama yang hanya memiliki teks author, publisher dan genre
+// dengan entitas katalog. Teks yang sama setelah dinormalisasi digabung menjadi satu entitas.
+// Aman dijalankan berulang kali karena hanya memproses komik yang belum terhubung
+func MigrateLegacy(db *gorm.DB) error {
+	var komik []models.Komik
+	err := db.Where("(author_id IS NULL AND author <> '') OR (publisher_id IS NULL AND publisher <> '') OR "+
+		"(genre <> '' AND id NOT IN (?))", db.Table("komik_genres").Select("komik_id")).
+		Find(&komik).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range komik {
+		k := &komik[i]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := Resolve(tx, k, nil); err != nil {
+				return err
+			}
+			// UpdateColumns dipakai agar updated_at dan version tidak berubah karena migrasi
+			err := tx.Model(k).UpdateColumns(map[string]interface{}{
+				"author_id":    k.AuthorID,
+				"author":       k.Author,
+				"publisher_id": k.PublisherID,
+				"publisher":    k.Publisher,
+				"genre":        k.Genre,
+			}).Error
+			if err != nil {
+				return err
+			}
+			return SaveGenres(tx, k)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(komik) > 0 {
+		slog.Info("Migrasi katalog: komik dihubungkan dengan author, publisher dan genre", "jumlah", len(komik))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"backend/alerts"
+	"backend/logging"
+)
+
+// ConnectNotifier memilih tujuan peringatan untuk admin, misalnya stok rendah, berdasarkan environment variable ALERT_NOTIFIERS,
+// berisi satu atau beberapa nilai dipisah koma:
+//   - "log" (default): peringatan ditulis ke log server
+//   - "email": dikirim lewat SMTP_HOST, SMTP_PORT (default 587), SMTP_USERNAME dan SMTP_PASSWORD
+//     dari ALERT_EMAIL_FROM ke ALERT_EMAIL_TO (beberapa alamat dipisah koma)
+//   - "webhook": dikirim sebagai JSON ke ALERT_WEBHOOK_URL
+func ConnectNotifier() alerts.Notifier {
+	var notifiers alerts.Multi
+	for _, name := range strings.Split(getEnv("ALERT_NOTIFIERS", "log"), ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "log":
+			notifiers = append(notifiers, alerts.LogNotifier{})
+		case "email":
+			notifiers = append(notifiers, &alerts.EmailNotifier{
+				Host:     os.Getenv("SMTP_HOST"),
+				Port:     getEnv("SMTP_PORT", "587"),
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     os.Getenv("ALERT_EMAIL_FROM"),
+				To:       splitList(os.Getenv("ALERT_EMAIL_TO")),
+			})
+		case "webhook":
+			url := os.Getenv("ALERT_WEBHOOK_URL")
+			if url == "" {
+				logging.Fatal("ALERT_WEBHOOK_URL wajib diisi untuk notifier webhook")
+			}
+			notifiers = append(notifiers, alerts.NewWebhookNotifier(url))
+		case "":
+		default:
+			logging.Fatal("Notifier pada ALERT_NOTIFIERS tidak dikenal", "notifier", name)
+		}
+	}
+	return notifiers
+}
+
+// LowStockInterval mengembalikan jarak pemeriksaan stok rendah dari LOW_STOCK_INTERVAL
+// (format durasi Go seperti "30s" atau "5m", default 1 menit)
+func LowStockInterval() time.Duration {
+	return getDuration("LOW_STOCK_INTERVAL", time.Minute)
+}
+
+// splitList memecah daftar nilai yang dipisah koma dan membuang nilai kosong
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -1,20 +1,278 @@
 package config
 
 import (
-	"log"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"backend/logging"
+	"backend/metrics"
+	"backend/tracing"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
-var DB *gorm.DB
+// maxConnectBackoff adalah jeda terlama di antara percobaan koneksi ke database
+const maxConnectBackoff = 30 * time.Second
+
+// replicaPingTimeout adalah batas waktu setiap pemeriksaan replika
+const replicaPingTimeout = 5 * time.Second
+
+// Database adalah koneksi ke database utama beserta replika bacanya
+type Database struct {
+	// DB menjalankan semua query di database utama, termasuk jika replika diatur
+	DB *gorm.DB
+	// ReadDB dipakai untuk request yang hanya membaca data. Query baca di luar transaksi
+	// dijalankan di salah satu replika secara acak, sedangkan transaksi dan query tulis tetap
+	// di database utama. Data dari replika dapat tertinggal sebentar dari database utama.
+	// Sama dengan DB jika replika tidak diatur
+	ReadDB *gorm.DB
+
+	replicas []*sql.DB      // Pool koneksi replika baca dari DB_REPLICA_DSNS
+	policy   *replicaPolicy // Status replika yang dipakai dbresolver, nil tanpa replika
+}
+
+// NewDatabase membuat Database tanpa replika dari koneksi yang sudah dibuka
+func NewDatabase(db *gorm.DB) *Database {
+	return &Database{DB: db, ReadDB: db}
+}
+
+// ConnectDatabase menghubungkan ke database utama dari DB_DSN (wajib diisi), mencoba ulang
+// dengan jeda yang makin lama jika database belum dapat dihubungi. Jika DB_REPLICA_DSNS diisi
+// (beberapa DSN dipisah koma), query baca dari ReadDB diarahkan ke replika yang tersedia
+func ConnectDatabase() *Database {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		logging.Fatal("DB_DSN wajib diisi")
+	}
+	db, err := openDatabase(dsn)
+	if err != nil {
+		logging.Fatal("Gagal terhubung ke database", "error", err)
+	}
+	primary, err := db.DB()
+	if err != nil {
+		logging.Fatal("Gagal terhubung ke database", "error", err)
+	}
+	configurePool(primary)
+	database := &Database{ReadDB: db}
+
+	if value := os.Getenv("DB_REPLICA_DSNS"); value != "" {
+		var dialectors []gorm.Dialector
+		for i, dsn := range strings.Split(value, ",") {
+			replica, err := sql.Open("mysql", strings.TrimSpace(dsn))
+			if err != nil {
+				logging.Fatal("DSN replika database tidak valid", "replika", i+1, "error", err)
+			}
+			configurePool(replica)
+			database.replicas = append(database.replicas, replica)
+			dialectors = append(dialectors, mysql.New(mysql.Config{Conn: replica}))
+		}
+		database.policy = newReplicaPolicy(primary, len(database.replicas))
+		// Replika yang belum dapat dihubungi tidak menghentikan server; query baca dijalankan
+		// di replika lain atau database utama sampai replika tersebut tersedia
+		database.CheckReplicas(context.Background())
+		err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors, Policy: database.policy}))
+		if err != nil {
+			logging.Fatal("Gagal memasang replika database", "error", err)
+		}
+		slog.Info("Replika database diatur", "jumlah", len(database.replicas))
+	}
+
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		logging.Fatal("Gagal memasang metrics database", "error", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		logging.Fatal("Gagal memasang tracing database", "error", err)
+	}
+	database.DB = db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+	slog.Info("Berhasil terhubung ke database")
+	return database
+}
+
+// Ping memastikan koneksi ke database utama masih dapat dipakai. Replika tidak diperiksa
+// karena query baca dialihkan ke database utama jika semua replika tidak tersedia
+func (d *Database) Ping(ctx context.Context) error {
+	primary, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return primary.PingContext(ctx)
+}
+
+// CheckReplicas memeriksa setiap replika, mencatat perubahan statusnya di log dan metric
+// komik_db_replica_up milik Metrics di ctx, lalu mengatur agar query baca hanya diarahkan ke
+// replika yang tersedia
+func (d *Database) CheckReplicas(ctx context.Context) {
+	m := metrics.FromContext(ctx)
+	for i, replica := range d.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := replica.PingContext(pingCtx)
+		cancel()
+
+		nama := fmt.Sprintf("replica_%d", i+1)
+		up := err == nil
+		if m != nil {
+			if up {
+				m.DBReplicaUp.WithLabelValues(nama).Set(1)
+			} else {
+				m.DBReplicaUp.WithLabelValues(nama).Set(0)
+			}
+		}
+		if was := d.policy.healthy[i].Swap(up); was != up {
+			if up {
+				slog.Info("Replika database tersedia", "replika", i+1)
+			} else {
+				slog.Warn("Replika database tidak dapat dihubungi, query baca dialihkan", "replika", i+1, "error", err)
+			}
+		}
+	}
+}
+
+// MonitorReplicas menjalankan CheckReplicas segera lalu setiap interval sampai ctx dibatalkan
+func (d *Database) MonitorReplicas(ctx context.Context, interval time.Duration) {
+	if len(d.replicas) == 0 {
+		return
+	}
+	d.CheckReplicas(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.CheckReplicas(ctx)
+		}
+	}
+}
+
+// ReplicaCheckInterval mengembalikan jarak pemeriksaan replika database dari
+// DB_REPLICA_CHECK_INTERVAL (default 10 detik)
+func ReplicaCheckInterval() time.Duration {
+	return getDuration("DB_REPLICA_CHECK_INTERVAL", 10*time.Second)
+}
+
+// replicaPolicy memilih salah satu replika yang tersedia secara acak untuk query baca. Jika
+// tidak ada replika yang tersedia, query dijalankan di database utama
+type replicaPolicy struct {
+	primary gorm.ConnPool
+	healthy []atomic.Bool // Urutannya sama dengan replika yang didaftarkan ke dbresolver
+}
+
+// newReplicaPolicy membuat policy untuk n replika yang dianggap tersedia sampai diperiksa
+func newReplicaPolicy(primary gorm.ConnPool, n int) *replicaPolicy {
+	p := &replicaPolicy{primary: primary, healthy: make([]atomic.Bool, n)}
+	for i := range p.healthy {
+		p.healthy[i].Store(true)
+	}
+	return p
+}
+
+// Resolve dipanggil dbresolver untuk setiap query baca
+func (p *replicaPolicy) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	tersedia := 0
+	for i := range pools {
+		if i < len(p.healthy) && p.healthy[i].Load() {
+			tersedia++
+		}
+	}
+	if tersedia == 0 {
+		return p.primary
+	}
+	pilih := rand.IntN(tersedia)
+	for i, pool := range pools {
+		if i < len(p.healthy) && p.healthy[i].Load() {
+			if pilih == 0 {
+				return pool
+			}
+			pilih--
+		}
+	}
+	return p.primary
+}
+
+// Close menutup semua koneksi di pool database utama dan replika
+func (d *Database) Close() error {
+	pools, err := d.pools()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pool := range pools {
+		errs = append(errs, pool.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// openDatabase membuka koneksi ke database sebanyak DB_CONNECT_ATTEMPTS kali (default 10)
+// dengan jeda awal DB_CONNECT_BACKOFF (default 1 detik) yang digandakan setiap kali gagal
+func openDatabase(dsn string) (*gorm.DB, error) {
+	attempts := getInt("DB_CONNECT_ATTEMPTS", 10)
+	backoff := getDuration("DB_CONNECT_BACKOFF", time.Second)
+	// Error dari GORM saat membuka koneksi tidak dicatat dua kali, percobaan yang gagal
+	// sudah dicatat di bawah
+	config := &gorm.Config{Logger: logger.Discard}
 
-func ConnectDatabase() {
-	dsn := "admin:admin123@tcp(database-1.cv6oi4oimtxt.ap-southeast-1.rds.amazonaws.com:3306)/komik?charset=utf8mb4&parseTime=True&loc=Local"
 	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	for attempt := 1; ; attempt++ {
+		var db *gorm.DB
+		if db, err = gorm.Open(mysql.Open(dsn), config); err == nil {
+			db.Logger = logging.NewGormLogger(SlowQueryThreshold())
+			return db, nil
+		}
+		if attempt >= attempts {
+			return nil, err
+		}
+		slog.Warn("Database belum dapat dihubungi, mencoba lagi", "percobaan", attempt, "jeda", backoff.String(), "error", err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+// configurePool mengatur batas pool koneksi dari DB_MAX_OPEN_CONNS (default 25),
+// DB_MAX_IDLE_CONNS (default 10), DB_CONN_MAX_LIFETIME (default 30 menit) dan
+// DB_CONN_MAX_IDLE_TIME (default 5 menit)
+func configurePool(db *sql.DB) {
+	db.SetMaxOpenConns(getInt("DB_MAX_OPEN_CONNS", 25))
+	db.SetMaxIdleConns(getInt("DB_MAX_IDLE_CONNS", 10))
+	db.SetConnMaxLifetime(getDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute))
+	db.SetConnMaxIdleTime(getDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute))
+}
+
+// RegisterMetrics mencatat statistik pool database utama ("primary") dan setiap replika
+// ("replica_1", ...) ke reg
+func (d *Database) RegisterMetrics(reg prometheus.Registerer) error {
+	pools, err := d.pools()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, pool := range pools {
+		nama := "primary"
+		if i > 0 {
+			nama = fmt.Sprintf("replica_%d", i)
+		}
+		errs = append(errs, metrics.RegisterDBStats(reg, pool, nama))
+	}
+	return errors.Join(errs...)
+}
+
+// pools mengembalikan pool koneksi database utama diikuti replika
+func (d *Database) pools() ([]*sql.DB, error) {
+	primary, err := d.DB.DB()
 	if err != nil {
-		log.Fatal("Gagal terhubung ke database:", err)
+		return nil, err
 	}
-	log.Println("Berhasil terhubung ke database!")
+	return append([]*sql.DB{primary}, d.replicas...), nil
 }
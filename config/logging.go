@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"backend/logging"
+)
+
+// SetupLogger menjadikan logger JSON ke stdout sebagai logger bawaan slog dan paket log.
+// Level diambil dari LOG_LEVEL (debug, info, warn atau error, default info)
+func SetupLogger() {
+	level, err := logging.ParseLevel(getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		logging.Fatal("LOG_LEVEL tidak valid", "error", err)
+	}
+	slog.SetDefault(logging.New(os.Stdout, level))
+}
+
+// SlowQueryThreshold mengembalikan batas waktu query database yang dicatat sebagai query
+// lambat dari DB_SLOW_QUERY (default 200 milidetik)
+func SlowQueryThreshold() time.Duration {
+	return getDuration("DB_SLOW_QUERY", 200*time.Millisecond)
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"os"
+
+	"backend/isbn"
+	"backend/logging"
+)
+
+// ConnectMetadata memilih provider data buku berdasarkan environment variable METADATA_PROVIDER:
+//   - "openlibrary" (default): API Open Library, alamatnya dapat diganti dengan OPENLIBRARY_URL
+//   - "none": pencarian data buku dinonaktifkan, nil dikembalikan
+func ConnectMetadata() isbn.MetadataProvider {
+	switch provider := getEnv("METADATA_PROVIDER", "openlibrary"); provider {
+	case "openlibrary":
+		return isbn.NewOpenLibrary(os.Getenv("OPENLIBRARY_URL"))
+	case "none":
+		return nil
+	default:
+		logging.Fatal("METADATA_PROVIDER tidak dikenal", "provider", provider)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+
+	"backend/catalog"
+	"backend/inventory"
+	"backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MigrateDatabase menyesuaikan struktur tabel dengan model lalu memindahkan data lama ke
+// struktur barunya
+func MigrateDatabase(db *gorm.DB) error {
+	err := db.AutoMigrate(
+		&models.User{},
+		&models.Author{},
+		&models.Publisher{},
+		&models.Genre{},
+		&models.Komik{},
+		&models.Comment{},
+		&models.CommentReaction{},
+		&models.CommentRevision{},
+		&models.Series{},
+		&models.Volume{},
+		&models.Chapter{},
+		&models.Diskon{},
+		&models.StockMovement{},
+		&models.LowStockAlert{},
+		&models.Reservation{},
+		&models.Wishlist{},
+		&models.Notification{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.AuditLog{},
+		&models.DataMigration{},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Hubungkan teks author, publisher dan genre lama dengan tabel katalog
+	if err := catalog.MigrateLegacy(db); err != nil {
+		return fmt.Errorf("migrasi data katalog: %w", err)
+	}
+
+	// Buat saldo awal catatan stok untuk komik yang sudah ada sebelum catatan stok
+	// diperkenalkan. Setelah itu selisih stok hanya dilaporkan, tidak diperbaiki otomatis
+	err = runOnce(db, "inventory_saldo_awal", func(tx *gorm.DB) error {
+		_, err := inventory.Reconcile(tx, nil, "saldo awal")
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("migrasi catatan stok: %w", err)
+	}
+	if err := reportStockDrift(db); err != nil {
+		return fmt.Errorf("pemeriksaan catatan stok: %w", err)
+	}
+	slog.Info("Migrasi database selesai")
+	return nil
+}
+
+// runOnce menjalankan migrasi data bernama nama di dalam transaksi jika belum pernah
+// dijalankan. Jika beberapa server dijalankan bersamaan, hanya satu yang menjalankannya
+func runOnce(db *gorm.DB, nama string, migrate func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.DataMigration{Nama: nama})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		slog.Info("Menjalankan migrasi data", "migrasi", nama)
+		return migrate(tx)
+	})
+}
+
+// reportStockDrift mencatat komik yang stoknya berbeda dengan catatan perubahan stoknya.
+// Selisih tidak diperbaiki otomatis agar penyebabnya dapat diperiksa terlebih dahulu,
+// lalu diperbaiki admin lewat POST /admin/inventory/reconcile
+func reportStockDrift(db *gorm.DB) error {
+	drift, err := inventory.Drift(db)
+	if err != nil {
+		return err
+	}
+	if len(drift) > 0 {
+		komikIDs := make([]uint, len(drift))
+		for i, hasil := range drift {
+			komikIDs[i] = hasil.KomikID
+		}
+		slog.Warn("Stok komik berbeda dengan catatan perubahan stok", "jumlah", len(drift), "komik_id", komikIDs)
+	}
+	return nil
+}
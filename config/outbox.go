@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// OutboxInterval mengembalikan jarak penerusan event outbox ke WebSocket dan webhook dari
+// OUTBOX_INTERVAL (default 1 detik)
+func OutboxInterval() time.Duration {
+	return getDuration("OUTBOX_INTERVAL", time.Second)
+}
+
+// OutboxGapTimeout mengembalikan lama relay realtime menunggu ID event yang belum tersimpan
+// sebelum melanjutkan ke event berikutnya dari OUTBOX_GAP_TIMEOUT (default 2 detik)
+func OutboxGapTimeout() time.Duration {
+	return getDuration("OUTBOX_GAP_TIMEOUT", 2*time.Second)
+}
+
+// OutboxRetention mengembalikan lama event outbox yang sudah diteruskan disimpan dari
+// OUTBOX_RETENTION (default 24 jam)
+func OutboxRetention() time.Duration {
+	return getDuration("OUTBOX_RETENTION", 24*time.Hour)
+}
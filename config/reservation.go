@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// ReservationTTL mengembalikan lama reservasi stok saat checkout dari RESERVATION_TTL
+// (default 15 menit)
+func ReservationTTL() time.Duration {
+	return getDuration("RESERVATION_TTL", 15*time.Minute)
+}
+
+// ReservationSweepInterval mengembalikan jarak pelepasan reservasi yang kedaluwarsa dari
+// RESERVATION_SWEEP_INTERVAL (default 1 menit)
+func ReservationSweepInterval() time.Duration {
+	return getDuration("RESERVATION_SWEEP_INTERVAL", time.Minute)
+}
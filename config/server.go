@@ -0,0 +1,50 @@
+package config
+
+import (
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"backend/logging"
+)
+
+// ShutdownTimeout mengembalikan batas waktu menunggu request dan koneksi WebSocket selesai
+// saat server berhenti dari SHUTDOWN_TIMEOUT (default 30 detik)
+func ShutdownTimeout() time.Duration {
+	return getDuration("SHUTDOWN_TIMEOUT", 30*time.Second)
+}
+
+// MetricsAddr mengembalikan alamat listener metrics Prometheus dari METRICS_ADDR (default
+// ":9090"). Port ini tidak boleh dibuka ke publik
+func MetricsAddr() string {
+	return getEnv("METRICS_ADDR", ":9090")
+}
+
+// JWTSecret mengembalikan kunci untuk menandatangani dan memvalidasi token JWT dari
+// JWT_SECRET (wajib diisi)
+func JWTSecret() []byte {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		logging.Fatal("JWT_SECRET wajib diisi")
+	}
+	return []byte(secret)
+}
+
+// TrustedProxies mengembalikan alamat IP atau CIDR reverse proxy yang header
+// X-Forwarded-For-nya dipercaya dari TRUSTED_PROXIES (dipisah koma). Jika kosong, tidak ada
+// proxy yang dipercaya dan IP client diambil dari alamat koneksi
+func TrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			logging.Fatal("Environment variable tidak valid", "key", "TRUSTED_PROXIES", "value", proxy)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies
+}
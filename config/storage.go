@@ -0,0 +1,77 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"backend/logging"
+	"backend/media"
+)
+
+// ConnectStorage memilih tempat penyimpanan file seperti cover komik berdasarkan environment
+// variable STORAGE_DRIVER:
+//   - "local" (default): file disimpan di direktori STORAGE_DIR (default "uploads")
+//   - "s3": file disimpan di bucket S3_BUCKET pada S3_ENDPOINT menggunakan
+//     S3_ACCESS_KEY, S3_SECRET_KEY, S3_REGION dan S3_USE_SSL
+func ConnectStorage() media.Storage {
+	var storage media.Storage
+	var err error
+	switch driver := getEnv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		storage, err = media.NewLocalStorage(getEnv("STORAGE_DIR", "uploads"))
+	case "s3":
+		storage, err = media.NewS3Storage(media.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			UseSSL:    getEnv("S3_USE_SSL", "true") == "true",
+		})
+	default:
+		logging.Fatal("STORAGE_DRIVER tidak dikenal", "driver", driver)
+	}
+	if err != nil {
+		logging.Fatal("Gagal menyiapkan storage", "error", err)
+	}
+	slog.Info("Storage siap digunakan")
+	return storage
+}
+
+// getEnv mengambil environment variable atau nilai bawaan jika tidak diisi
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getDuration mengambil environment variable berformat durasi Go (misalnya "30s" atau "5m")
+// atau nilai bawaan jika tidak diisi
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logging.Fatal("Environment variable tidak valid", "key", key, "value", value)
+	}
+	return duration
+}
+
+// getInt mengambil environment variable berupa bilangan bulat positif atau nilai bawaan
+// jika tidak diisi
+func getInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		logging.Fatal("Environment variable tidak valid", "key", key, "value", value)
+	}
+	return n
+}
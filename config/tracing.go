@@ -0,0 +1,55 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"backend/logging"
+	"backend/tracing"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SetupTracing memasang tracing OpenTelemetry berdasarkan environment variable TRACING_EXPORTER:
+//   - "none" (default): span tidak dikirim, trace context W3C dari request tetap diteruskan
+//   - "stdout": span ditulis ke stdout dalam format JSON
+//   - "otlp": span dikirim lewat OTLP/HTTP ke collector di OTEL_EXPORTER_OTLP_ENDPOINT
+//     (default http://localhost:4318)
+//
+// Nama service diambil dari OTEL_SERVICE_NAME (default komik-api) dan bagian trace baru yang
+// dicatat dari TRACING_SAMPLE_RATIO (0 sampai 1, default 1). Fungsi yang dikembalikan
+// mengirim sisa span dan dipanggil saat server berhenti
+func SetupTracing() func(context.Context) error {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch name := getEnv("TRACING_EXPORTER", "none"); name {
+	case "none":
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		endpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+		exporter, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(endpoint))
+	default:
+		logging.Fatal("TRACING_EXPORTER tidak dikenal", "exporter", name)
+	}
+	if err != nil {
+		logging.Fatal("Gagal membuat exporter tracing", "error", err)
+	}
+	return tracing.Setup(exporter, getEnv("OTEL_SERVICE_NAME", "komik-api"), sampleRatio())
+}
+
+// sampleRatio mengambil TRACING_SAMPLE_RATIO, bagian trace baru yang dicatat
+func sampleRatio() float64 {
+	value := os.Getenv("TRACING_SAMPLE_RATIO")
+	if value == "" {
+		return 1
+	}
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		logging.Fatal("Environment variable tidak valid", "key", "TRACING_SAMPLE_RATIO", "value", value)
+	}
+	return ratio
+}
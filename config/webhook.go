@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"backend/webhook"
+)
+
+// WebhookSender membuat pengirim webhook dengan batas waktu setiap pengiriman dari
+// WEBHOOK_TIMEOUT (default 10 detik)
+func WebhookSender() *webhook.Sender {
+	return webhook.NewSender(getDuration("WEBHOOK_TIMEOUT", 10*time.Second))
+}
+
+// WebhookInterval mengembalikan jarak pemeriksaan antrean pengiriman webhook dari
+// WEBHOOK_INTERVAL (default 10 detik)
+func WebhookInterval() time.Duration {
+	return getDuration("WEBHOOK_INTERVAL", 10*time.Second)
+}
+
+// WebhookMaxAttempts mengembalikan jumlah percobaan pengiriman webhook sebelum ditandai
+// gagal dari WEBHOOK_MAX_ATTEMPTS (default 8, sekitar 1 jam setelah event terjadi)
+func WebhookMaxAttempts() int {
+	return getInt("WEBHOOK_MAX_ATTEMPTS", 8)
+}
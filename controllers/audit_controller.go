@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"backend/audit"
+	"backend/models"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AuditHandler menangani request audit log
+type AuditHandler struct {
+	database
+}
+
+// NewAuditHandler membuat AuditHandler. readDB dipakai untuk query baca dari request GET
+func NewAuditHandler(db, readDB *gorm.DB) *AuditHandler {
+	return &AuditHandler{database{db: db, readDB: readDB}}
+}
+
+// recordAudit mencatat aksi admin yang sedang diproses beserta perubahan datanya. Pelaku, IP
+// dan ID request diambil dari request. Dipanggil di dalam transaksi aksinya
+func recordAudit(c *gin.Context, tx *gorm.DB, aksi, entitas string, entitasID uint, before, after interface{}) error {
+	return currentActor(c).Audit(tx, aksi, entitas, entitasID, before, after)
+}
+
+// GetAuditLogs godoc
+// @Summary Menampilkan audit log
+// @Description Menampilkan aksi admin dari yang terbaru beserta pelaku, perubahan data (nilai sebelum dan sesudah setiap field yang berubah), IP dan ID request. Audit log tidak dapat diubah atau dihapus
+// @Tags Admin
+// @Produce application/json
+// @Param user_id query int false "Filter ID admin pelaku"
+// @Param aksi query string false "Filter aksi (create, update, delete, import, reconcile, redeliver)"
+// @Param entitas query string false "Filter entitas, misalnya komik atau comment"
+// @Param entitas_id query int false "Filter ID data"
+// @Param request_id query string false "Filter ID request"
+// @Param dari query string false "Waktu awal (RFC3339 atau YYYY-MM-DD)"
+// @Param sampai query string false "Waktu akhir (RFC3339 atau YYYY-MM-DD, tanggal akhir ikut disertakan)"
+// @Param limit query int false "Jumlah audit log (default 50, maksimal 500)"
+// @Param offset query int false "Jumlah audit log yang dilewati"
+// @Success 200 {array} models.AuditLog
+// @Failure 400 {object} map[string]string "Filter tidak valid"
+// @Router /admin/audit-logs [get]
+// @Security BearerAuth
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	filter, err := auditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	logs := []models.AuditLog{}
+	err = filter.Apply(h.requestDB(c)).Order("id DESC").Limit(limit).Offset(offset).Find(&logs).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, logs)
+}
+
+// ExportAuditLogs godoc
+// @Summary Mengekspor audit log
+// @Description Mengunduh audit log yang sesuai filter sebagai CSV atau JSON, diurutkan dari yang terlama. Data dikirim bertahap sehingga log besar tidak perlu dimuat sekaligus
+// @Tags Admin
+// @Produce text/csv,application/json
+// @Param format query string false "Format file" Enums(csv, json) default(csv)
+// @Param user_id query int false "Filter ID admin pelaku"
+// @Param aksi query string false "Filter aksi"
+// @Param entitas query string false "Filter entitas"
+// @Param entitas_id query int false "Filter ID data"
+// @Param request_id query string false "Filter ID request"
+// @Param dari query string false "Waktu awal (RFC3339 atau YYYY-MM-DD)"
+// @Param sampai query string false "Waktu akhir (RFC3339 atau YYYY-MM-DD, tanggal akhir ikut disertakan)"
+// @Success 200 {file} file "File ekspor"
+// @Failure 400 {object} map[string]string "Filter atau format tidak valid"
+// @Router /admin/audit-logs/export [get]
+// @Security BearerAuth
+func (h *AuditHandler) ExportAuditLogs(c *gin.Context) {
+	filter, err := auditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	var contentType string
+	var export func(db *gorm.DB, f audit.Filter, w io.Writer) error
+	switch format {
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+		export = audit.ExportCSV
+	case "json":
+		contentType = "application/json; charset=utf-8"
+		export = audit.ExportJSON
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format harus csv atau json"})
+		return
+	}
+
+	filename := fmt.Sprintf("audit-log-%s.%s", time.Now().Format("20060102-150405"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Status(http.StatusOK)
+
+	// Header sudah terkirim, error di tengah ekspor hanya dapat dicatat
+	if err := export(h.requestDB(c), filter, c.Writer); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Gagal mengekspor audit log", "format", format, "error", err)
+	}
+}
+
+// auditFilter membaca filter audit log dari query string
+func auditFilter(c *gin.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		Aksi:      c.Query("aksi"),
+		Entitas:   c.Query("entitas"),
+		RequestID: c.Query("request_id"),
+	}
+	var err error
+	if filter.UserID, err = queryID(c, "user_id"); err != nil {
+		return filter, err
+	}
+	if filter.EntitasID, err = queryID(c, "entitas_id"); err != nil {
+		return filter, err
+	}
+	if filter.Dari, err = queryTime(c, "dari", false); err != nil {
+		return filter, err
+	}
+	if filter.Sampai, err = queryTime(c, "sampai", true); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// queryID membaca ID dari query string, nil jika tidak diisi
+func queryID(c *gin.Context, key string) (*uint, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s harus berupa angka", key)
+	}
+	result := uint(id)
+	return &result, nil
+}
+
+// queryTime membaca waktu RFC3339 atau tanggal YYYY-MM-DD dari query string, nil jika tidak
+// diisi. Jika akhirHari true, tanggal tanpa jam diartikan sampai akhir hari tersebut
+func queryTime(c *gin.Context, key string, akhirHari bool) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%s harus berupa waktu RFC3339 atau tanggal YYYY-MM-DD", key)
+	}
+	if akhirHari {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
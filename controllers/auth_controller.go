@@ -1,20 +1,27 @@
 package controllers
 
 import (
-	"backend/config"
-	"backend/models"
+	"backend/metrics"
+	"backend/services"
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
-// Secret Key untuk JWT
-var jwtSecretKey = []byte("your_secret_key")
+// AuthHandler menangani login user
+type AuthHandler struct {
+	auth    *services.AuthService
+	metrics *metrics.Metrics
+}
+
+// NewAuthHandler membuat AuthHandler yang mencatat hasil setiap login ke m
+func NewAuthHandler(auth *services.AuthService, m *metrics.Metrics) *AuthHandler {
+	return &AuthHandler{auth: auth, metrics: m}
+}
 
 // Login user
-func Login(c *gin.Context) {
+func (h *AuthHandler) Login(c *gin.Context) {
 	var input struct {
 		Username string `json:"username" binding:"required"`
 		Password string `json:"password" binding:"required"`
@@ -24,32 +31,19 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	// Cari user di database
-	var user models.User
-	if err := config.DB.Where("username = ?", input.Username).First(&user).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Username atau password salah"})
-		return
-	}
-
-	// Bandingkan password plaintext secara langsung
-	if user.Password != input.Password {
+	tokenString, err := h.auth.Login(c.Request.Context(), input.Username, input.Password)
+	if errors.Is(err, services.ErrLoginGagal) {
+		h.metrics.Logins.WithLabelValues(metrics.LoginGagal).Inc()
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Username atau password salah"})
 		return
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"role_id": user.RoleID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
-	})
-	tokenString, err := token.SignedString([]byte("your_secret_key"))
-
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat token"})
 		return
 	}
 
 	// Kirimkan token ke user
+	h.metrics.Logins.WithLabelValues(metrics.LoginSukses).Inc()
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Login berhasil",
 		"token":   tokenString,
@@ -0,0 +1,85 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend/controllers"
+	"backend/metrics"
+	"backend/services"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestLogin(t *testing.T) {
+	key := []byte("kunci-test")
+	users := fakeUsers{"budi": {ID: 5, Username: "budi", Password: "rahasia", RoleID: 2}}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		hasil  string // Label metric login yang bertambah, kosong jika tidak ada
+	}{
+		{"password benar", `{"username":"budi","password":"rahasia"}`, http.StatusOK, metrics.LoginSukses},
+		{"password salah", `{"username":"budi","password":"salah"}`, http.StatusUnauthorized, metrics.LoginGagal},
+		{"user tidak ada", `{"username":"ani","password":"rahasia"}`, http.StatusUnauthorized, metrics.LoginGagal},
+		{"tanpa password", `{"username":"budi"}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := prometheus.NewRegistry()
+			m, err := metrics.New(reg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := controllers.NewAuthHandler(services.NewAuthService(users, key), m)
+			w := serve(http.MethodPost, "/login", h.Login, "/login", tt.body, 0, 0)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if got := logins(t, reg); got != tt.hasil {
+				t.Errorf("login tercatat %q, want %q", got, tt.hasil)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Token string `json:"token"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			claims := jwt.MapClaims{}
+			if _, err := jwt.ParseWithClaims(response.Token, claims, func(*jwt.Token) (interface{}, error) { return key, nil }); err != nil {
+				t.Fatalf("token tidak valid: %v", err)
+			}
+			if claims["user_id"] != float64(5) || claims["role_id"] != float64(2) {
+				t.Errorf("claims = %v", claims)
+			}
+		})
+	}
+}
+
+// logins mengembalikan label hasil login yang tercatat di reg, kosong jika tidak ada
+func logins(t *testing.T, reg *prometheus.Registry) string {
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasil := ""
+	for _, family := range families {
+		if family.GetName() != "komik_logins_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				hasil += label.GetValue()
+			}
+		}
+	}
+	return hasil
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"backend/bulk"
+	"backend/models"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// BulkHandler menangani impor dan ekspor data komik
+type BulkHandler struct {
+	database
+}
+
+// NewBulkHandler membuat BulkHandler. readDB dipakai untuk query baca dari request GET
+func NewBulkHandler(db, readDB *gorm.DB) *BulkHandler {
+	return &BulkHandler{database{db: db, readDB: readDB}}
+}
+
+// maksimalUkuranImpor adalah ukuran file impor terbesar yang diterima (10 MB)
+const maksimalUkuranImpor = 10 << 20
+
+// ImportKomik godoc
+// @Summary Mengimpor katalog komik
+// @Description Mengimpor komik dari CSV (baris pertama berisi nama kolom) atau NDJSON (satu objek JSON per baris). File dapat dikirim sebagai body dengan Content-Type text/csv atau application/x-ndjson, atau sebagai field 'file' pada multipart/form-data. Kolom: nama, isbn10, isbn13, author, genre, tahun_terbit, publisher, stok, reorder_level, harga, mata_uang. Komik dengan ISBN atau nama yang sama diperbarui, selain itu dibuat baru. Semua baris disimpan dalam satu transaksi: jika ada baris yang tidak valid tidak ada data yang disimpan
+// @Tags Admin
+// @Accept text/csv,application/x-ndjson,multipart/form-data
+// @Produce application/json
+// @Param dry_run query bool false "Hanya memeriksa data tanpa menyimpan"
+// @Param format query string false "Format file jika tidak dapat ditentukan dari Content-Type atau nama file" Enums(csv, ndjson)
+// @Param file formData file false "File impor"
+// @Success 200 {object} bulk.Report
+// @Failure 422 {object} bulk.Report "Ada baris yang tidak valid"
+// @Router /admin/komik/import [post]
+// @Security BearerAuth
+func (h *BulkHandler) ImportKomik(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maksimalUkuranImpor)
+
+	body, format, err := importSource(c)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Ukuran file impor maksimal 10 MB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	var rows []bulk.Row
+	switch format {
+	case "csv":
+		rows, err = bulk.ReadCSV(body)
+	case "ndjson":
+		rows, err = bulk.ReadNDJSON(body)
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Format impor harus csv atau ndjson"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File tidak dapat dibaca: " + err.Error()})
+		return
+	}
+
+	report, err := bulk.Import(h.requestDB(c), rows, bulk.Options{
+		DryRun:         c.Query("dry_run") == "true",
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		UserID:         currentUserID(c),
+		Audit: func(tx *gorm.DB, report *bulk.Report) error {
+			return recordAudit(c, tx, models.AuditImport, "komik", 0, nil, gin.H{
+				"total": report.Total, "dibuat": report.Dibuat, "diperbarui": report.Diperbarui, "baris": report.Baris,
+			})
+		},
+	})
+	if errors.Is(err, bulk.ErrAdaBarisGagal) {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportKomik godoc
+// @Summary Mengekspor katalog komik
+// @Description Mengunduh seluruh katalog komik sebagai CSV, JSON atau Excel. Data dikirim bertahap sehingga katalog besar tidak perlu dimuat sekaligus
+// @Tags Admin
+// @Produce text/csv,application/json,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Format file" Enums(csv, json, xlsx) default(csv)
+// @Success 200 {file} file "File ekspor"
+// @Router /admin/komik/export [get]
+// @Security BearerAuth
+func (h *BulkHandler) ExportKomik(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+
+	var contentType string
+	var export func(db *gorm.DB, w io.Writer) error
+	switch format {
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+		export = bulk.ExportCSV
+	case "json":
+		contentType = "application/json; charset=utf-8"
+		export = bulk.ExportJSON
+	case "xlsx":
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		export = bulk.ExportXLSX
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format harus csv, json atau xlsx"})
+		return
+	}
+
+	filename := fmt.Sprintf("komik-%s.%s", time.Now().Format("20060102-150405"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Status(http.StatusOK)
+
+	// Header sudah terkirim, error di tengah ekspor hanya dapat dicatat
+	if err := export(h.requestDB(c), c.Writer); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Gagal mengekspor komik", "format", format, "error", err)
+	}
+}
+
+// importSource mengambil isi file impor dari multipart atau body request beserta formatnya
+func importSource(c *gin.Context) (io.ReadCloser, string, error) {
+	format := c.Query("format")
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	if mediaType == "multipart/form-data" {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			return nil, "", fmt.Errorf("file impor wajib dikirim pada field 'file': %w", err)
+		}
+		if format == "" {
+			format = formatFromName(header.Filename)
+		}
+		return file, format, nil
+	}
+
+	if format == "" {
+		switch mediaType {
+		case "text/csv":
+			format = "csv"
+		case "application/x-ndjson", "application/jsonl", "application/json":
+			format = "ndjson"
+		}
+	}
+	return c.Request.Body, format, nil
+}
+
+// formatFromName menentukan format impor dari ekstensi nama file
+func formatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return "csv"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	}
+	return ""
+}
@@ -0,0 +1,357 @@
+package controllers
+
+import (
+	"backend/audit"
+	"backend/catalog"
+	"backend/models"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CatalogHandler menangani request katalog author, publisher dan genre
+type CatalogHandler struct {
+	database
+}
+
+// NewCatalogHandler membuat CatalogHandler. readDB dipakai untuk query baca dari request GET
+func NewCatalogHandler(db, readDB *gorm.DB) *CatalogHandler {
+	return &CatalogHandler{database{db: db, readDB: readDB}}
+}
+
+// KatalogInput adalah data yang dikirim saat membuat atau mengubah author, publisher dan genre
+type KatalogInput struct {
+	Nama string `json:"nama" binding:"notblank,max=255"`
+}
+
+// katalogResource menjelaskan satu jenis entitas katalog yang dikelola lewat endpoint yang sama
+type katalogResource struct {
+	label   string
+	entitas string // Nama entitas di audit log
+	newItem func() models.EntitasKatalog
+	newList func() interface{}
+}
+
+var (
+	authorResource = katalogResource{
+		label:   "Author",
+		entitas: "author",
+		newItem: func() models.EntitasKatalog { return &models.Author{} },
+		newList: func() interface{} { return &[]models.Author{} },
+	}
+	publisherResource = katalogResource{
+		label:   "Publisher",
+		entitas: "publisher",
+		newItem: func() models.EntitasKatalog { return &models.Publisher{} },
+		newList: func() interface{} { return &[]models.Publisher{} },
+	}
+	genreResource = katalogResource{
+		label:   "Genre",
+		entitas: "genre",
+		newItem: func() models.EntitasKatalog { return &models.Genre{} },
+		newList: func() interface{} { return &[]models.Genre{} },
+	}
+)
+
+// GetAuthors godoc
+// @Summary Menampilkan semua author
+// @Tags Katalog
+// @Produce application/json
+// @Success 200 {array} models.Author
+// @Router /authors [get]
+// @Security BearerAuth
+func (h *CatalogHandler) GetAuthors(c *gin.Context) { h.listKatalog(c, authorResource) }
+
+// GetAuthorByID godoc
+// @Summary Menampilkan detail author
+// @Tags Katalog
+// @Produce application/json
+// @Param id path int true "ID Author"
+// @Success 200 {object} models.Author
+// @Router /authors/{id} [get]
+// @Security BearerAuth
+func (h *CatalogHandler) GetAuthorByID(c *gin.Context) { h.getKatalog(c, authorResource) }
+
+// CreateAuthor godoc
+// @Summary Menambahkan author baru
+// @Description Nama yang sama setelah dinormalisasi (huruf besar/kecil, urutan kata, tanda baca) dianggap duplikat
+// @Tags Katalog
+// @Accept application/json
+// @Produce application/json
+// @Param data body KatalogInput true "Data Author"
+// @Success 201 {object} models.Author
+// @Failure 409 {object} map[string]interface{} "Author sudah ada"
+// @Router /authors [post]
+// @Security BearerAuth
+func (h *CatalogHandler) CreateAuthor(c *gin.Context) { h.createKatalog(c, authorResource) }
+
+// UpdateAuthor godoc
+// @Summary Mengubah nama author
+// @Description Nama author pada komik yang memakai author ini ikut diperbarui
+// @Tags Katalog
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Author"
+// @Param data body KatalogInput true "Data Author"
+// @Success 200 {object} models.Author
+// @Router /authors/{id} [put]
+// @Security BearerAuth
+func (h *CatalogHandler) UpdateAuthor(c *gin.Context) { h.updateKatalog(c, authorResource) }
+
+// DeleteAuthor godoc
+// @Summary Menghapus author
+// @Description Author yang masih dipakai oleh komik tidak dapat dihapus
+// @Tags Katalog
+// @Param id path int true "ID Author"
+// @Success 200 {string} string "Author berhasil dihapus"
+// @Failure 409 {object} map[string]string "Author masih dipakai oleh komik"
+// @Router /authors/{id} [delete]
+// @Security BearerAuth
+func (h *CatalogHandler) DeleteAuthor(c *gin.Context) { h.deleteKatalog(c, authorResource) }
+
+// GetPublishers godoc
+// @Summary Menampilkan semua publisher
+// @Tags Katalog
+// @Produce application/json
+// @Success 200 {array} models.Publisher
+// @Router /publishers [get]
+// @Security BearerAuth
+func (h *CatalogHandler) GetPublishers(c *gin.Context) { h.listKatalog(c, publisherResource) }
+
+// GetPublisherByID godoc
+// @Summary Menampilkan detail publisher
+// @Tags Katalog
+// @Produce application/json
+// @Param id path int true "ID Publisher"
+// @Success 200 {object} models.Publisher
+// @Router /publishers/{id} [get]
+// @Security BearerAuth
+func (h *CatalogHandler) GetPublisherByID(c *gin.Context) { h.getKatalog(c, publisherResource) }
+
+// CreatePublisher godoc
+// @Summary Menambahkan publisher baru
+// @Description Nama yang sama setelah dinormalisasi (huruf besar/kecil, urutan kata, tanda baca) dianggap duplikat
+// @Tags Katalog
+// @Accept application/json
+// @Produce application/json
+// @Param data body KatalogInput true "Data Publisher"
+// @Success 201 {object} models.Publisher
+// @Failure 409 {object} map[string]interface{} "Publisher sudah ada"
+// @Router /publishers [post]
+// @Security BearerAuth
+func (h *CatalogHandler) CreatePublisher(c *gin.Context) { h.createKatalog(c, publisherResource) }
+
+// UpdatePublisher godoc
+// @Summary Mengubah nama publisher
+// @Description Nama publisher pada komik yang memakai publisher ini ikut diperbarui
+// @Tags Katalog
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Publisher"
+// @Param data body KatalogInput true "Data Publisher"
+// @Success 200 {object} models.Publisher
+// @Router /publishers/{id} [put]
+// @Security BearerAuth
+func (h *CatalogHandler) UpdatePublisher(c *gin.Context) { h.updateKatalog(c, publisherResource) }
+
+// DeletePublisher godoc
+// @Summary Menghapus publisher
+// @Description Publisher yang masih dipakai oleh komik tidak dapat dihapus
+// @Tags Katalog
+// @Param id path int true "ID Publisher"
+// @Success 200 {string} string "Publisher berhasil dihapus"
+// @Failure 409 {object} map[string]string "Publisher masih dipakai oleh komik"
+// @Router /publishers/{id} [delete]
+// @Security BearerAuth
+func (h *CatalogHandler) DeletePublisher(c *gin.Context) { h.deleteKatalog(c, publisherResource) }
+
+// GetGenres godoc
+// @Summary Menampilkan semua genre
+// @Tags Katalog
+// @Produce application/json
+// @Success 200 {array} models.Genre
+// @Router /genres [get]
+// @Security BearerAuth
+func (h *CatalogHandler) GetGenres(c *gin.Context) { h.listKatalog(c, genreResource) }
+
+// GetGenreByID godoc
+// @Summary Menampilkan detail genre
+// @Tags Katalog
+// @Produce application/json
+// @Param id path int true "ID Genre"
+// @Success 200 {object} models.Genre
+// @Router /genres/{id} [get]
+// @Security BearerAuth
+func (h *CatalogHandler) GetGenreByID(c *gin.Context) { h.getKatalog(c, genreResource) }
+
+// CreateGenre godoc
+// @Summary Menambahkan genre baru
+// @Description Nama yang sama setelah dinormalisasi (huruf besar/kecil, urutan kata, tanda baca) dianggap duplikat
+// @Tags Katalog
+// @Accept application/json
+// @Produce application/json
+// @Param data body KatalogInput true "Data Genre"
+// @Success 201 {object} models.Genre
+// @Failure 409 {object} map[string]interface{} "Genre sudah ada"
+// @Router /genres [post]
+// @Security BearerAuth
+func (h *CatalogHandler) CreateGenre(c *gin.Context) { h.createKatalog(c, genreResource) }
+
+// UpdateGenre godoc
+// @Summary Mengubah nama genre
+// @Description Teks genre pada komik yang memakai genre ini ikut diperbarui
+// @Tags Katalog
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Genre"
+// @Param data body KatalogInput true "Data Genre"
+// @Success 200 {object} models.Genre
+// @Router /genres/{id} [put]
+// @Security BearerAuth
+func (h *CatalogHandler) UpdateGenre(c *gin.Context) { h.updateKatalog(c, genreResource) }
+
+// DeleteGenre godoc
+// @Summary Menghapus genre
+// @Description Genre yang masih dipakai oleh komik tidak dapat dihapus
+// @Tags Katalog
+// @Param id path int true "ID Genre"
+// @Success 200 {string} string "Genre berhasil dihapus"
+// @Failure 409 {object} map[string]string "Genre masih dipakai oleh komik"
+// @Router /genres/{id} [delete]
+// @Security BearerAuth
+func (h *CatalogHandler) DeleteGenre(c *gin.Context) { h.deleteKatalog(c, genreResource) }
+
+func (h *CatalogHandler) listKatalog(c *gin.Context, resource katalogResource) {
+	list := resource.newList()
+	if err := h.requestDB(c).Order("nama").Find(list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *CatalogHandler) getKatalog(c *gin.Context, resource katalogResource) {
+	item := resource.newItem()
+	if err := h.requestDB(c).First(item, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": resource.label + " tidak ditemukan"})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *CatalogHandler) createKatalog(c *gin.Context, resource katalogResource) {
+	var input KatalogInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	existing, found, err := findKatalogByKey(h.requestDB(c), resource, input.Nama, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if found {
+		c.JSON(http.StatusConflict, gin.H{"error": resource.label + " sudah ada", "data": existing})
+		return
+	}
+
+	item := resource.newItem()
+	data := item.DataKatalog()
+	data.Nama = strings.TrimSpace(input.Nama)
+	data.Kunci = catalog.Key(input.Nama)
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, resource.entitas, data.ID, nil, item)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+func (h *CatalogHandler) updateKatalog(c *gin.Context, resource katalogResource) {
+	item := resource.newItem()
+	if err := h.requestDB(c).First(item, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": resource.label + " tidak ditemukan"})
+		return
+	}
+
+	var input KatalogInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	data := item.DataKatalog()
+	existing, found, err := findKatalogByKey(h.requestDB(c), resource, input.Nama, data.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if found {
+		c.JSON(http.StatusConflict, gin.H{"error": resource.label + " dengan nama tersebut sudah ada", "data": existing})
+		return
+	}
+
+	before := audit.Snapshot(item)
+	data.Nama = strings.TrimSpace(input.Nama)
+	data.Kunci = catalog.Key(input.Nama)
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(item).Error; err != nil {
+			return err
+		}
+		// Perbarui teks author/publisher/genre pada komik yang memakai entitas ini
+		if err := catalog.SyncNames(tx, item); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditUpdate, resource.entitas, data.ID, before, item)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *CatalogHandler) deleteKatalog(c *gin.Context, resource katalogResource) {
+	item := resource.newItem()
+	if err := h.requestDB(c).First(item, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": resource.label + " tidak ditemukan"})
+		return
+	}
+
+	inUse, err := catalog.InUse(h.requestDB(c), item)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if inUse {
+		c.JSON(http.StatusConflict, gin.H{"error": resource.label + " masih dipakai oleh komik"})
+		return
+	}
+
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(item).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditDelete, resource.entitas, item.DataKatalog().ID, item, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": resource.label + " berhasil dihapus"})
+}
+
+// findKatalogByKey mencari entitas lain dengan nama yang sama setelah dinormalisasi
+func findKatalogByKey(db *gorm.DB, resource katalogResource, nama string, exceptID uint) (models.EntitasKatalog, bool, error) {
+	existing := resource.newItem()
+	result := db.Where("kunci = ? AND id <> ?", catalog.Key(nama), exceptID).Limit(1).Find(existing)
+	return existing, result.RowsAffected > 0, result.Error
+}
@@ -1,13 +1,23 @@
 package controllers
 
 import (
-	"backend/config"
 	"backend/models"
+	"backend/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+// CommentHandler menangani request komentar dan reaksinya
+type CommentHandler struct {
+	comments *services.CommentService
+}
+
+// NewCommentHandler membuat CommentHandler
+func NewCommentHandler(comments *services.CommentService) *CommentHandler {
+	return &CommentHandler{comments: comments}
+}
+
 // GetAllComments godoc
 // @Summary Menampilkan semua komentar
 // @Description Admin dapat melihat semua komentar, sedangkan user hanya dapat melihat komentarnya sendiri
@@ -16,38 +26,18 @@ import (
 // @Success 200 {array} models.Comment
 // @Router /comments [get]
 // @Security BearerAuth
-func GetAllComments(c *gin.Context) {
-	role, _ := c.Get("role_id")
-
-	if role == 1 {
-		// Admin dapat melihat semua komentar
-		var comments []models.Comment
-		if err := config.DB.Find(&comments).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusOK, comments)
+func (h *CommentHandler) GetAllComments(c *gin.Context) {
+	comments, err := h.comments.List(c.Request.Context(), currentActor(c))
+	if err != nil {
+		respondServiceError(c, err)
 		return
 	}
-
-	if role == 2 {
-		// User hanya dapat melihat komentar miliknya sendiri
-		userID, _ := c.Get("user_id")
-		var comments []models.Comment
-		if err := config.DB.Where("user_id = ?", userID).Find(&comments).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusOK, comments)
-		return
-	}
-
-	c.JSON(http.StatusForbidden, gin.H{"error": "Role tidak diizinkan untuk melihat komentar"})
+	c.JSON(http.StatusOK, comments)
 }
 
 // CreateComment godoc
 // @Summary Membuat komentar baru
-// @Description User dapat membuat komentar baru
+// @Description User dapat membuat komentar baru. Untuk membalas, sebut user lain dengan @username; user yang disebut dan sudah berkomentar pada komik yang sama mendapat notifikasi
 // @Tags Komentar
 // @Accept application/json
 // @Produce application/json
@@ -55,30 +45,48 @@ func GetAllComments(c *gin.Context) {
 // @Success 201 {object} models.Comment
 // @Router /comments [post]
 // @Security BearerAuth
-func CreateComment(c *gin.Context) {
-	role, _ := c.Get("role_id")
-	if role != 2 {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Hanya user yang dapat membuat komentar"})
-		return
-	}
-
+func (h *CommentHandler) CreateComment(c *gin.Context) {
 	var comment models.Comment
 	if err := c.ShouldBindJSON(&comment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
-
-	// Ambil user_id dari context
-	userID, _ := c.Get("user_id")
-	comment.UserID = userID.(uint)
-
-	if err := config.DB.Create(&comment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.comments.Create(c.Request.Context(), &comment, currentActor(c)); err != nil {
+		respondServiceError(c, err)
 		return
 	}
+	setETag(c, comment.Version)
 	c.JSON(http.StatusCreated, comment)
 }
 
+// GetCommentByID godoc
+// @Summary Menampilkan detail komentar
+// @Description Mengambil satu komentar beserta jumlah reaksinya
+// @Tags Komentar
+// @Produce application/json
+// @Param id path int true "ID Komentar"
+// @Success 200 {object} models.Comment
+// @Header 200 {string} ETag "Versi data komentar"
+// @Router /comments/{id} [get]
+// @Security BearerAuth
+func (h *CommentHandler) GetCommentByID(c *gin.Context) {
+	comment, err := h.comments.Get(c.Request.Context(), paramID(c, "id"), currentActor(c))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	setETag(c, comment.Version)
+	c.JSON(http.StatusOK, comment)
+}
+
+// CommentInput adalah data lengkap komentar yang dikirim saat PUT
+type CommentInput struct {
+	Komentar *string `json:"komentar" binding:"required"`
+}
+
+// commentPatchFields adalah field komentar yang dapat diubah lewat PATCH
+var commentPatchFields = []string{"komentar"}
+
 // UpdateComment godoc
 // @Summary Memperbarui komentar
 // @Description User dapat memperbarui komentarnya sendiri
@@ -86,69 +94,123 @@ func CreateComment(c *gin.Context) {
 // @Accept application/json
 // @Produce application/json
 // @Param id path int true "ID Komentar"
-// @Param data body models.Comment true "Data Komentar yang Diperbarui"
+// @Param If-Match header string true "ETag dari komentar yang akan diubah"
+// @Param data body CommentInput true "Data Komentar yang Diperbarui"
 // @Success 200 {object} models.Comment
+// @Failure 412 {object} map[string]string "Komentar sudah diubah oleh request lain"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
 // @Router /comments/{id} [put]
 // @Security BearerAuth
-func UpdateComment(c *gin.Context) {
-	role, _ := c.Get("role_id")
-	if role != 2 {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Hanya user yang dapat mengedit komentar"})
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	comment, ok := h.loadOwnCommentForWrite(c)
+	if !ok {
 		return
 	}
 
-	id := c.Param("id")
-	var comment models.Comment
-
-	if err := config.DB.First(&comment, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Komentar tidak ditemukan"})
+	var input CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
 		return
 	}
+	previous := comment.Komentar
+	comment.Komentar = *input.Komentar
+
+	h.saveComment(c, &comment, previous)
+}
 
-	userID, _ := c.Get("user_id")
-	if comment.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Tidak diizinkan mengedit komentar ini"})
+// PatchComment godoc
+// @Summary Memperbarui sebagian komentar
+// @Description User dapat memperbarui komentarnya sendiri menggunakan JSON Merge Patch (RFC 7396)
+// @Tags Komentar
+// @Accept application/merge-patch+json
+// @Produce application/json
+// @Param id path int true "ID Komentar"
+// @Param If-Match header string true "ETag dari komentar yang akan diubah"
+// @Param data body object true "Field komentar yang diubah (komentar)"
+// @Success 200 {object} models.Comment
+// @Failure 412 {object} map[string]string "Komentar sudah diubah oleh request lain"
+// @Failure 415 {object} map[string]string "Content-Type tidak didukung"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
+// @Router /comments/{id} [patch]
+// @Security BearerAuth
+func (h *CommentHandler) PatchComment(c *gin.Context) {
+	comment, ok := h.loadOwnCommentForWrite(c)
+	if !ok {
 		return
 	}
 
-	if err := c.ShouldBindJSON(&comment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	previous := comment.Komentar
+	if err := applyMergePatch(c, &comment, commentPatchFields...); err != nil {
+		respondPatchError(c, err)
 		return
 	}
 
-	config.DB.Save(&comment)
+	h.saveComment(c, &comment, previous)
+}
+
+// loadOwnCommentForWrite mengambil komentar milik user yang sedang login dan memeriksa header If-Match
+func (h *CommentHandler) loadOwnCommentForWrite(c *gin.Context) (models.Comment, bool) {
+	comment, err := h.comments.FindOwn(c.Request.Context(), paramID(c, "id"), currentActor(c))
+	if err != nil {
+		respondServiceError(c, err)
+		return comment, false
+	}
+	return comment, checkIfMatch(c, comment.Version)
+}
+
+// saveComment menyimpan perubahan komentar beserta revisinya lalu mengirim response
+func (h *CommentHandler) saveComment(c *gin.Context, comment *models.Comment, previous string) {
+	if err := h.comments.Update(c.Request.Context(), comment, previous, currentActor(c)); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	setETag(c, comment.Version)
 	c.JSON(http.StatusOK, comment)
 }
 
+// GetCommentHistory godoc
+// @Summary Menampilkan riwayat edit komentar
+// @Description Menampilkan isi komentar sebelum diedit, diurutkan dari yang paling lama. Hanya dapat diakses oleh pemilik komentar dan admin
+// @Tags Komentar
+// @Produce application/json
+// @Param id path int true "ID Komentar"
+// @Success 200 {array} models.CommentRevision
+// @Router /comments/{id}/history [get]
+// @Security BearerAuth
+func (h *CommentHandler) GetCommentHistory(c *gin.Context) {
+	revisions, err := h.comments.History(c.Request.Context(), paramID(c, "id"), currentActor(c))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
 // DeleteComment godoc
 // @Summary Menghapus komentar
-// @Description Admin dapat menghapus komentar siapa saja, sedangkan user hanya dapat menghapus komentarnya sendiri
+// @Description Admin dapat menghapus komentar siapa saja, sedangkan user hanya dapat menghapus komentarnya sendiri. Jika admin menghapus komentar user, pemiliknya mendapat notifikasi beserta alasannya. Riwayat edit komentar yang dihapus tetap disimpan dan dapat dilihat admin.
 // @Tags Komentar
 // @Param id path int true "ID Komentar"
+// @Param alasan query string false "Alasan penghapusan oleh admin, dikirim ke pemilik komentar"
+// @Param If-Match header string true "ETag dari komentar yang akan dihapus"
 // @Success 200 {string} string "Komentar berhasil dihapus"
+// @Failure 412 {object} map[string]string "Komentar sudah diubah oleh request lain"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
 // @Router /comments/{id} [delete]
 // @Security BearerAuth
-func DeleteComment(c *gin.Context) {
-	role, _ := c.Get("role_id")
-	id := c.Param("id")
-
-	var comment models.Comment
-	if err := config.DB.First(&comment, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Komentar tidak ditemukan"})
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	actor := currentActor(c)
+	comment, err := h.comments.FindDeletable(c.Request.Context(), paramID(c, "id"), actor)
+	if err != nil {
+		respondServiceError(c, err)
 		return
 	}
-
-	userID, _ := c.Get("user_id")
-
-	if role == 2 {
-		// User hanya bisa menghapus komentarnya sendiri
-		if comment.UserID != userID.(uint) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Tidak diizinkan menghapus komentar ini"})
-			return
-		}
+	if !checkIfMatch(c, comment.Version) {
+		return
+	}
+	if err := h.comments.Delete(c.Request.Context(), &comment, c.Query("alasan"), actor); err != nil {
+		respondWriteError(c, err)
+		return
 	}
-
-	// Admin dapat menghapus komentar siapa saja
-	config.DB.Delete(&comment)
 	c.JSON(http.StatusOK, gin.H{"message": "Komentar berhasil dihapus"})
 }
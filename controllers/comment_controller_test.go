@@ -0,0 +1,146 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend/controllers"
+	"backend/models"
+	"backend/services"
+)
+
+func newCommentHandler(comments *fakeComments) *controllers.CommentHandler {
+	komiks := newFakeKomiks(models.Komik{ID: 1, Nama: "One Piece", Version: 1})
+	return controllers.NewCommentHandler(services.NewCommentService(comments, komiks))
+}
+
+func TestCreateComment(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		roleID int
+		status int
+	}{
+		{"user berkomentar", `{"komik_id":1,"komentar":"Seru!"}`, 2, http.StatusCreated},
+		{"admin tidak dapat berkomentar", `{"komik_id":1,"komentar":"Seru!"}`, 1, http.StatusForbidden},
+		{"komik tidak ada", `{"komik_id":9,"komentar":"Seru!"}`, 2, http.StatusNotFound},
+		{"komentar kosong", `{"komik_id":1,"komentar":"   "}`, 2, http.StatusBadRequest},
+		{"tanpa komik_id", `{"komentar":"Seru!"}`, 2, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := newFakeComments()
+			h := newCommentHandler(comments)
+			w := serve(http.MethodPost, "/comments", h.CreateComment, "/comments", tt.body, 5, tt.roleID)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusCreated {
+				if len(comments.comment) != 0 {
+					t.Errorf("komentar tetap disimpan: %+v", comments.comment)
+				}
+				return
+			}
+
+			var comment models.Comment
+			if err := json.Unmarshal(w.Body.Bytes(), &comment); err != nil {
+				t.Fatal(err)
+			}
+			if comment.ID != 1 || comment.UserID != 5 || comment.Version != 1 {
+				t.Errorf("komentar = %+v", comment)
+			}
+		})
+	}
+}
+
+func TestGetAllComments(t *testing.T) {
+	comments := newFakeComments(
+		models.Comment{ID: 1, UserID: 5, KomikID: 1, Komentar: "Seru!", Version: 1},
+		models.Comment{ID: 2, UserID: 6, KomikID: 1, Komentar: "Bagus", Version: 1},
+	)
+	h := newCommentHandler(comments)
+
+	tests := []struct {
+		name   string
+		roleID int
+		jumlah int
+	}{
+		{"admin melihat semua komentar", 1, 2},
+		{"user hanya melihat komentarnya sendiri", 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(http.MethodGet, "/comments", h.GetAllComments, "/comments", "", 5, tt.roleID)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+			var list []models.Comment
+			if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list) != tt.jumlah {
+				t.Errorf("jumlah komentar = %d, want %d", len(list), tt.jumlah)
+			}
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   uint
+		ifMatch  string
+		status   int
+		komentar string
+	}{
+		{"pemilik komentar", 5, `"1"`, http.StatusOK, "Seru sekali!"},
+		{"bukan pemilik", 6, `"1"`, http.StatusForbidden, "Seru!"},
+		{"tanpa If-Match", 5, "", http.StatusPreconditionRequired, "Seru!"},
+		{"versi lama", 5, `"0"`, http.StatusPreconditionFailed, "Seru!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := newFakeComments(models.Comment{ID: 1, UserID: 5, KomikID: 1, Komentar: "Seru!", Version: 1})
+			h := newCommentHandler(comments)
+			var headers []string
+			if tt.ifMatch != "" {
+				headers = []string{"If-Match", tt.ifMatch}
+			}
+			w := serve(http.MethodPut, "/comments/:id", h.UpdateComment, "/comments/1", `{"komentar":"Seru sekali!"}`, tt.userID, 2, headers...)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if got := comments.comment[1].Komentar; got != tt.komentar {
+				t.Errorf("komentar = %q, want %q", got, tt.komentar)
+			}
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		roleID  int
+		status  int
+		dihapus bool
+	}{
+		{"pemilik komentar", 5, 2, http.StatusOK, true},
+		{"admin", 1, 1, http.StatusOK, true},
+		{"user lain", 6, 2, http.StatusForbidden, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := newFakeComments(models.Comment{ID: 1, UserID: 5, KomikID: 1, Komentar: "Seru!", Version: 1})
+			h := newCommentHandler(comments)
+			w := serve(http.MethodDelete, "/comments/:id", h.DeleteComment, "/comments/1", "", tt.userID, tt.roleID, "If-Match", `"1"`)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if _, dihapus := comments.deleted[1]; dihapus != tt.dihapus {
+				t.Errorf("dihapus = %v, want %v", dihapus, tt.dihapus)
+			}
+		})
+	}
+}
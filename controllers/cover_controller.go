@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"backend/media"
+	"backend/models"
+	"backend/repository"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maksimalUkuranCover adalah ukuran file cover terbesar yang dapat diunggah (5 MB)
+const maksimalUkuranCover = 5 << 20
+
+// tipeCover adalah jenis gambar yang diterima sebagai cover, ditentukan dari isi file
+var tipeCover = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// CoverHandler menangani request cover komik
+type CoverHandler struct {
+	database
+	storage media.Storage
+}
+
+// NewCoverHandler membuat CoverHandler yang menyimpan file cover di storage
+func NewCoverHandler(db, readDB *gorm.DB, storage media.Storage) *CoverHandler {
+	return &CoverHandler{database: database{db: db, readDB: readDB}, storage: storage}
+}
+
+// UploadCover godoc
+// @Summary Mengunggah cover komik
+// @Description Mengunggah gambar cover (JPEG, PNG, GIF atau WebP, maksimal 5 MB). Jenis file ditentukan dari isinya, bukan dari nama file. Thumbnail ukuran kecil, sedang dan besar dibuat otomatis dan cover lama dihapus
+// @Tags Komik
+// @Accept multipart/form-data
+// @Produce application/json
+// @Param id path int true "ID Komik"
+// @Param If-Match header string true "ETag dari komik yang akan diubah"
+// @Param cover formData file true "File gambar cover"
+// @Success 200 {object} models.Komik
+// @Failure 412 {object} map[string]string "Komik sudah diubah oleh request lain"
+// @Failure 413 {object} map[string]string "File terlalu besar"
+// @Failure 415 {object} map[string]string "Jenis file tidak didukung"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
+// @Router /komik/{id}/cover [post]
+// @Security BearerAuth
+func (h *CoverHandler) UploadCover(c *gin.Context) {
+	var komik models.Komik
+	if err := h.requestDB(c).First(&komik, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+		return
+	}
+	if !checkIfMatch(c, komik.Version) {
+		return
+	}
+
+	// Sisakan ruang untuk bagian lain dari body multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maksimalUkuranCover+1<<20)
+	file, header, err := c.Request.FormFile("cover")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Ukuran file cover maksimal 5 MB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File cover wajib dikirim pada field 'cover'"})
+		return
+	}
+	defer file.Close()
+	if header.Size > maksimalUkuranCover {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Ukuran file cover maksimal 5 MB"})
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maksimalUkuranCover+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(data) > maksimalUkuranCover {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Ukuran file cover maksimal 5 MB"})
+		return
+	}
+
+	mtype := mimetype.Detect(data)
+	if !tipeCover[mtype.String()] {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Cover harus berupa gambar JPEG, PNG, GIF atau WebP"})
+		return
+	}
+	thumbnails, err := media.Thumbnails(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Simpan file terlebih dahulu, data komik baru diubah setelah semua file tersimpan
+	ctx := c.Request.Context()
+	key := media.CoverKey(komik.ID, data, mtype.Extension())
+	files := map[string][]byte{key: data}
+	for ukuran, thumbnail := range thumbnails {
+		files[media.ThumbnailKey(key, ukuran)] = thumbnail
+	}
+	for fileKey, content := range files {
+		contentType := "image/jpeg"
+		if fileKey == key {
+			contentType = mtype.String()
+		}
+		if err := h.storage.Put(ctx, fileKey, bytes.NewReader(content), int64(len(content)), contentType); err != nil {
+			media.DeleteCover(h.storage, key)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	oldKey := komik.Cover
+	before := gin.H{"cover": oldKey, "version": komik.Version}
+	komik.Cover = key
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := repository.UpdateVersioned(tx, &komik, &komik.Version); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditUpdate, "komik", komik.ID, before, gin.H{"cover": key, "version": komik.Version})
+	})
+	if err != nil {
+		if key != oldKey {
+			media.DeleteCover(h.storage, key)
+		}
+		respondWriteError(c, err)
+		return
+	}
+	if oldKey != "" && oldKey != key {
+		media.DeleteCover(h.storage, oldKey)
+	}
+
+	h.requestDB(c).Preload("Genres", repository.OrderGenres).Preload("Volume").First(&komik, komik.ID)
+	setETag(c, komik.Version)
+	c.JSON(http.StatusOK, komik)
+}
+
+// GetCover godoc
+// @Summary Menampilkan cover komik
+// @Description Menampilkan gambar cover asli atau thumbnail-nya. Dapat diakses tanpa token agar bisa dipakai langsung di tag img. URL dengan parameter v (dari cover_url) di-cache selamanya oleh browser
+// @Tags Komik
+// @Produce image/jpeg,image/png,image/gif,image/webp
+// @Param id path int true "ID Komik"
+// @Param ukuran query string false "Ukuran thumbnail" Enums(kecil, sedang, besar)
+// @Param v query string false "Versi cover dari cover_url"
+// @Success 200 {file} file "Gambar cover"
+// @Success 304 {string} string "Cover tidak berubah"
+// @Router /komik/{id}/cover [get]
+func (h *CoverHandler) GetCover(c *gin.Context) {
+	var komik models.Komik
+	if err := h.requestDB(c).Select("id", "cover").First(&komik, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+		return
+	}
+	if komik.Cover == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Komik belum memiliki cover"})
+		return
+	}
+
+	key := komik.Cover
+	ukuran := c.Query("ukuran")
+	if ukuran != "" {
+		if _, ok := media.UkuranThumbnail[ukuran]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ukuran harus kecil, sedang atau besar"})
+			return
+		}
+		key = media.ThumbnailKey(key, ukuran)
+	}
+
+	hash := media.CoverHash(komik.Cover)
+	etag := strconv.Quote(hash + "-" + ukuran)
+	if c.Query("v") == hash {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		// URL tanpa versi tetap sama saat cover diganti, jadi browser harus memeriksa ulang
+		c.Header("Cache-Control", "public, no-cache")
+	}
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	reader, object, err := h.storage.Get(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, media.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File cover tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+	c.DataFromReader(http.StatusOK, object.Size, object.ContentType, reader, nil)
+}
+
+// DeleteCover godoc
+// @Summary Menghapus cover komik
+// @Description Menghapus cover beserta semua thumbnail-nya
+// @Tags Komik
+// @Param id path int true "ID Komik"
+// @Param If-Match header string true "ETag dari komik yang akan diubah"
+// @Success 200 {string} string "Cover berhasil dihapus"
+// @Failure 412 {object} map[string]string "Komik sudah diubah oleh request lain"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
+// @Router /komik/{id}/cover [delete]
+// @Security BearerAuth
+func (h *CoverHandler) DeleteCover(c *gin.Context) {
+	var komik models.Komik
+	if err := h.requestDB(c).First(&komik, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+		return
+	}
+	if komik.Cover == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Komik belum memiliki cover"})
+		return
+	}
+	if !checkIfMatch(c, komik.Version) {
+		return
+	}
+
+	oldKey := komik.Cover
+	before := gin.H{"cover": oldKey, "version": komik.Version}
+	komik.Cover = ""
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := repository.UpdateVersioned(tx, &komik, &komik.Version); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditUpdate, "komik", komik.ID, before, gin.H{"cover": "", "version": komik.Version})
+	})
+	if err != nil {
+		respondWriteError(c, err)
+		return
+	}
+	media.DeleteCover(h.storage, oldKey)
+	setETag(c, komik.Version)
+	c.JSON(http.StatusOK, gin.H{"message": "Cover berhasil dihapus"})
+}
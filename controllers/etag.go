@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"backend/catalog"
+	"backend/repository"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const pesanVersionConflict = "Data telah diubah oleh pengguna lain, muat ulang data terlebih dahulu"
+
+// etag membentuk nilai header ETag dari versi data
+func etag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// setETag menambahkan header ETag pada response
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", etag(version))
+}
+
+// checkIfMatch memastikan header If-Match dikirim dan sesuai dengan versi data saat ini.
+// Jika tidak sesuai, response 428 atau 412 langsung dikirim dan fungsi mengembalikan false
+func checkIfMatch(c *gin.Context, version uint) bool {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "Header If-Match wajib diisi"})
+		return false
+	}
+
+	current := etag(version)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+
+	setETag(c, version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": pesanVersionConflict})
+	return false
+}
+
+// respondWriteError mengirim response 412 untuk konflik versi, 400 untuk relasi katalog
+// yang tidak ditemukan, 409 untuk ISBN yang sudah dipakai dan 500 untuk error lainnya
+func respondWriteError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": pesanVersionConflict})
+		return
+	}
+	if errors.Is(err, catalog.ErrNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, catalog.ErrISBNDipakai) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
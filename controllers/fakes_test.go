@@ -0,0 +1,209 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+
+	"backend/models"
+	"backend/repository"
+	"backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	validation.Register()
+}
+
+// fakeKomiks adalah repository.KomikRepository di memori
+type fakeKomiks struct {
+	komik  map[uint]models.Komik
+	nextID uint
+}
+
+func newFakeKomiks(komik ...models.Komik) *fakeKomiks {
+	f := &fakeKomiks{komik: map[uint]models.Komik{}}
+	for _, k := range komik {
+		f.komik[k.ID] = k
+		f.nextID = max(f.nextID, k.ID)
+	}
+	return f
+}
+
+func (f *fakeKomiks) List(ctx context.Context, filter repository.KomikFilter) ([]models.Komik, error) {
+	komik := []models.Komik{}
+	for id := uint(1); id <= f.nextID; id++ {
+		if k, ok := f.komik[id]; ok {
+			komik = append(komik, k)
+		}
+	}
+	return komik, nil
+}
+
+func (f *fakeKomiks) Get(ctx context.Context, id uint) (models.Komik, error) {
+	k, ok := f.komik[id]
+	if !ok {
+		return k, repository.ErrNotFound
+	}
+	return k, nil
+}
+
+func (f *fakeKomiks) Find(ctx context.Context, id uint) (models.Komik, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeKomiks) FindByISBN(ctx context.Context, isbn10, isbn13 *string) (models.Komik, error) {
+	for _, k := range f.komik {
+		if sameString(k.ISBN10, isbn10) || sameString(k.ISBN13, isbn13) {
+			return k, nil
+		}
+	}
+	return models.Komik{}, repository.ErrNotFound
+}
+
+func (f *fakeKomiks) Create(ctx context.Context, komik *models.Komik, actor repository.Actor) error {
+	f.nextID++
+	komik.ID = f.nextID
+	f.komik[komik.ID] = *komik
+	return nil
+}
+
+func (f *fakeKomiks) Update(ctx context.Context, komik *models.Komik, previous *models.Komik, actor repository.Actor) error {
+	if f.komik[komik.ID].Version != komik.Version {
+		return repository.ErrVersionConflict
+	}
+	komik.Version++
+	f.komik[komik.ID] = *komik
+	return nil
+}
+
+func (f *fakeKomiks) Delete(ctx context.Context, komik *models.Komik, actor repository.Actor) error {
+	if f.komik[komik.ID].Version != komik.Version {
+		return repository.ErrVersionConflict
+	}
+	delete(f.komik, komik.ID)
+	return nil
+}
+
+// fakeComments adalah repository.CommentRepository di memori. Komentar yang dihapus
+// dipindahkan ke deleted
+type fakeComments struct {
+	comment map[uint]models.Comment
+	deleted map[uint]models.Comment
+	nextID  uint
+}
+
+func newFakeComments(comment ...models.Comment) *fakeComments {
+	f := &fakeComments{comment: map[uint]models.Comment{}, deleted: map[uint]models.Comment{}}
+	for _, k := range comment {
+		f.comment[k.ID] = k
+		f.nextID = max(f.nextID, k.ID)
+	}
+	return f
+}
+
+func (f *fakeComments) List(ctx context.Context, filter repository.CommentFilter) ([]models.Comment, error) {
+	comments := []models.Comment{}
+	for id := uint(1); id <= f.nextID; id++ {
+		k, ok := f.comment[id]
+		if !ok || (filter.UserID != 0 && k.UserID != filter.UserID) || (filter.KomikID != 0 && k.KomikID != filter.KomikID) {
+			continue
+		}
+		comments = append(comments, k)
+	}
+	return comments, nil
+}
+
+func (f *fakeComments) Get(ctx context.Context, id uint) (models.Comment, error) {
+	k, ok := f.comment[id]
+	if !ok {
+		return k, repository.ErrNotFound
+	}
+	return k, nil
+}
+
+func (f *fakeComments) Find(ctx context.Context, id uint) (models.Comment, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeComments) GetWithDeleted(ctx context.Context, id uint) (models.Comment, error) {
+	if k, ok := f.deleted[id]; ok {
+		return k, nil
+	}
+	return f.Get(ctx, id)
+}
+
+func (f *fakeComments) History(ctx context.Context, id uint) ([]models.CommentRevision, error) {
+	return []models.CommentRevision{}, nil
+}
+
+func (f *fakeComments) AttachReactions(ctx context.Context, comments []models.Comment, userID uint) error {
+	return nil
+}
+
+func (f *fakeComments) Create(ctx context.Context, comment *models.Comment) error {
+	f.nextID++
+	comment.ID = f.nextID
+	f.comment[comment.ID] = *comment
+	return nil
+}
+
+func (f *fakeComments) Update(ctx context.Context, comment *models.Comment, previous string, editorID uint) error {
+	if f.comment[comment.ID].Version != comment.Version {
+		return repository.ErrVersionConflict
+	}
+	comment.Version++
+	f.comment[comment.ID] = *comment
+	return nil
+}
+
+func (f *fakeComments) Delete(ctx context.Context, comment *models.Comment, deletion repository.CommentDeletion) error {
+	if f.comment[comment.ID].Version != comment.Version {
+		return repository.ErrVersionConflict
+	}
+	f.deleted[comment.ID] = *comment
+	delete(f.comment, comment.ID)
+	return nil
+}
+
+func (f *fakeComments) ToggleReaction(ctx context.Context, comment *models.Comment, userID uint, reaksi string) error {
+	return nil
+}
+
+// fakeUsers adalah repository.UserRepository di memori
+type fakeUsers map[string]models.User
+
+func (f fakeUsers) FindByUsername(ctx context.Context, username string) (models.User, error) {
+	user, ok := f[username]
+	if !ok {
+		return user, repository.ErrNotFound
+	}
+	return user, nil
+}
+
+func sameString(a, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// serve menjalankan satu request ke handler yang didaftarkan pada route. Middleware
+// autentikasi diganti dengan user_id dan role_id yang diberikan (0 berarti tanpa token)
+func serve(method, route string, handler gin.HandlerFunc, path, body string, userID uint, roleID int, headers ...string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		if userID != 0 {
+			c.Set("user_id", userID)
+			c.Set("role_id", roleID)
+		}
+	}, handler)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
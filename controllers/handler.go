@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"backend/repository"
+	"backend/services"
+	"backend/validation"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Handlers berisi semua handler HTTP aplikasi yang didaftarkan di routes. Handler komik,
+// komentar, login dan ISBN memakai service atau repository sehingga dapat diuji dengan fake;
+// handler lainnya masih membaca dan mengubah data langsung lewat GORM (lihat database)
+type Handlers struct {
+	Auth         *AuthHandler
+	Komik        *KomikHandler
+	Comment      *CommentHandler
+	Cover        *CoverHandler
+	ISBN         *ISBNHandler
+	WebSocket    *WebSocketHandler
+	Catalog      *CatalogHandler
+	Series       *SeriesHandler
+	Pricing      *PricingHandler
+	Inventory    *InventoryHandler
+	Bulk         *BulkHandler
+	Webhook      *WebhookHandler
+	Audit        *AuditHandler
+	Reservation  *ReservationHandler
+	Wishlist     *WishlistHandler
+	Notification *NotificationHandler
+	Health       *HealthHandler
+}
+
+// database adalah koneksi database untuk handler yang membaca dan mengubah data langsung
+// lewat GORM
+type database struct {
+	db     *gorm.DB // Database utama
+	readDB *gorm.DB // Sama dengan db, tetapi query baca di luar transaksi dapat dijalankan di replika
+}
+
+// requestDB mengembalikan koneksi database yang membawa context request, sehingga log query
+// menyertakan ID request dan query berhenti jika client memutus koneksi. Query baca dari
+// request GET di luar transaksi dijalankan di replika database jika replika diatur
+func (d database) requestDB(c *gin.Context) *gorm.DB {
+	if c.Request.Method == http.MethodGet {
+		return d.readDB.WithContext(c.Request.Context())
+	}
+	return d.db.WithContext(c.Request.Context())
+}
+
+// currentActor mengembalikan user yang login beserta IP dan ID request-nya
+func currentActor(c *gin.Context) repository.Actor {
+	return repository.Actor{
+		UserID:    c.GetUint("user_id"),
+		RoleID:    c.GetInt("role_id"),
+		IP:        c.ClientIP(),
+		RequestID: c.GetString("request_id"),
+	}
+}
+
+// currentUserID mengembalikan ID user yang login, nil jika request tidak memakai token
+func currentUserID(c *gin.Context) *uint {
+	userID, ok := c.Get("user_id")
+	if !ok {
+		return nil
+	}
+	id := userID.(uint)
+	return &id
+}
+
+// paramID mengambil ID dari parameter path. ID yang tidak valid menjadi 0, yang tidak
+// pernah dipakai oleh data mana pun
+func paramID(c *gin.Context, key string) uint {
+	id, _ := strconv.ParseUint(c.Param(key), 10, 64)
+	return uint(id)
+}
+
+// respondServiceError mengirim response sesuai jenis error dari service: 404 untuk data
+// yang tidak ditemukan, 403 untuk aksi yang tidak diizinkan, 400 untuk data yang tidak valid
+// dan status dari respondWriteError untuk error lainnya
+func respondServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		if _, ok := validation.Messages(err, ""); ok {
+			respondBindError(c, err)
+			return
+		}
+		respondWriteError(c, err)
+	}
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyPingTimeout adalah batas waktu ping database pada readiness check
+const readyPingTimeout = 2 * time.Second
+
+// ReadinessResponse adalah hasil pemeriksaan kesiapan server menerima request
+type ReadinessResponse struct {
+	Status   string `json:"status"`           // "siap" atau "tidak_siap"
+	Database string `json:"database"`         // "ok" atau pesan error ping database
+	Migrasi  string `json:"migrasi"`          // "selesai" atau "belum_selesai"
+	Server   string `json:"server,omitempty"` // "berhenti" jika server sedang dimatikan
+}
+
+// Readiness adalah keadaan server yang diperiksa readiness check
+type Readiness interface {
+	// Ping memastikan koneksi ke database utama masih dapat dipakai
+	Ping(ctx context.Context) error
+	// Migrated melaporkan apakah migrasi database sudah selesai
+	Migrated() bool
+	// ShuttingDown melaporkan apakah server sedang berhenti
+	ShuttingDown() bool
+}
+
+// HealthHandler menangani liveness dan readiness check
+type HealthHandler struct {
+	readiness Readiness
+}
+
+// NewHealthHandler membuat HealthHandler yang memeriksa readiness
+func NewHealthHandler(readiness Readiness) *HealthHandler {
+	return &HealthHandler{readiness: readiness}
+}
+
+// Healthz godoc
+// @Summary Liveness check
+// @Description Selalu 200 selama proses server berjalan dan dapat melayani request. Tidak memeriksa database
+// @Tags Health
+// @Produce application/json
+// @Success 200 {object} map[string]string
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz godoc
+// @Summary Readiness check
+// @Description 200 jika database utama dapat dihubungi dan migrasi sudah selesai. Replika database tidak diperiksa. 503 jika salah satunya gagal atau server sedang berhenti, sehingga load balancer tidak mengirim request baru
+// @Tags Health
+// @Produce application/json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	res := ReadinessResponse{Status: "siap", Database: "ok", Migrasi: "selesai"}
+	ready := true
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readyPingTimeout)
+	defer cancel()
+	if err := h.readiness.Ping(ctx); err != nil {
+		res.Database = err.Error()
+		ready = false
+	}
+	if !h.readiness.Migrated() {
+		res.Migrasi = "belum_selesai"
+		ready = false
+	}
+	if h.readiness.ShuttingDown() {
+		res.Server = "berhenti"
+		ready = false
+	}
+
+	if !ready {
+		res.Status = "tidak_siap"
+		c.JSON(http.StatusServiceUnavailable, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"backend/inventory"
+	"backend/models"
+	"backend/repository"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InventoryHandler menangani request catatan dan rekonsiliasi stok
+type InventoryHandler struct {
+	database
+}
+
+// NewInventoryHandler membuat InventoryHandler. readDB dipakai untuk query baca dari request GET
+func NewInventoryHandler(db, readDB *gorm.DB) *InventoryHandler {
+	return &InventoryHandler{database{db: db, readDB: readDB}}
+}
+
+// StockMovementInput adalah perubahan stok yang dicatat manual oleh admin
+type StockMovementInput struct {
+	Delta     int    `json:"delta" binding:"required"` // Positif untuk restock/return, negatif untuk sale
+	Alasan    string `json:"alasan" binding:"oneof=restock sale adjustment return"`
+	Referensi string `json:"referensi" binding:"max=255"`
+}
+
+// RiwayatStok adalah catatan perubahan stok komik beserta hasil rekonsiliasinya
+type RiwayatStok struct {
+	inventory.Rekonsiliasi
+	Movements []models.StockMovement `json:"movements"`
+}
+
+// GetStockMovements godoc
+// @Summary Menampilkan riwayat stok komik
+// @Description Menampilkan catatan perubahan stok komik dari yang terbaru, beserta perbandingan stok dengan jumlah seluruh catatan
+// @Tags Admin
+// @Produce application/json
+// @Param id path int true "ID Komik"
+// @Param limit query int false "Jumlah catatan (default 50, maksimal 500)"
+// @Param offset query int false "Jumlah catatan yang dilewati"
+// @Success 200 {object} RiwayatStok
+// @Router /admin/komik/{id}/stock [get]
+// @Security BearerAuth
+func (h *InventoryHandler) GetStockMovements(c *gin.Context) {
+	var komik models.Komik
+	if err := h.requestDB(c).Select("id").First(&komik, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	rekonsiliasi, err := inventory.Check(h.requestDB(c), komik.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	riwayat := RiwayatStok{Rekonsiliasi: rekonsiliasi, Movements: []models.StockMovement{}}
+	err = h.requestDB(c).Where("komik_id = ?", komik.ID).Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).Find(&riwayat.Movements).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, riwayat)
+}
+
+// CreateStockMovement godoc
+// @Summary Mencatat perubahan stok komik
+// @Description Menambah atau mengurangi stok komik sekaligus mencatatnya, misalnya saat barang datang dari supplier (restock) atau dikembalikan pembeli (return)
+// @Tags Admin
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Komik"
+// @Param data body StockMovementInput true "Perubahan stok"
+// @Success 201 {object} models.StockMovement
+// @Failure 409 {object} map[string]string "Stok tidak mencukupi"
+// @Router /admin/komik/{id}/stock [post]
+// @Security BearerAuth
+func (h *InventoryHandler) CreateStockMovement(c *gin.Context) {
+	komikID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+		return
+	}
+
+	var input StockMovementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	switch {
+	case (input.Alasan == models.AlasanRestock || input.Alasan == models.AlasanReturn) && input.Delta < 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Delta untuk restock dan return harus positif"})
+		return
+	case input.Alasan == models.AlasanSale && input.Delta > 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Delta untuk sale harus negatif"})
+		return
+	}
+
+	movement := models.StockMovement{
+		KomikID:   uint(komikID),
+		Delta:     input.Delta,
+		Alasan:    input.Alasan,
+		UserID:    currentUserID(c),
+		Referensi: input.Referensi,
+	}
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := inventory.Record(tx, &movement); err != nil {
+			return err
+		}
+		if err := notifyBackInStock(tx, &movement); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, "stock_movement", movement.ID, nil, movement)
+	})
+	if err != nil {
+		respondStockError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, movement)
+}
+
+// ReconcileStock godoc
+// @Summary Merekonsiliasi catatan stok
+// @Description Mencari komik yang stoknya berbeda dengan jumlah catatan perubahan stok, lalu menambahkan catatan adjustment sebesar selisihnya. Stok komik tidak diubah
+// @Tags Admin
+// @Produce application/json
+// @Success 200 {array} inventory.Rekonsiliasi
+// @Router /admin/inventory/reconcile [post]
+// @Security BearerAuth
+func (h *InventoryHandler) ReconcileStock(c *gin.Context) {
+	var hasil []inventory.Rekonsiliasi
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if hasil, err = inventory.Reconcile(tx, currentUserID(c), "rekonsiliasi"); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditReconcile, "inventory", 0, nil, gin.H{"rekonsiliasi": hasil})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, hasil)
+}
+
+// respondStockError mengirim response untuk error perubahan stok
+func respondStockError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, inventory.ErrKomikTidakDitemukan):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+	case errors.Is(err, inventory.ErrStokTidakCukup):
+		c.JSON(http.StatusConflict, gin.H{"error": "Stok tidak mencukupi"})
+	case errors.Is(err, inventory.ErrStokTidakTersedia):
+		c.JSON(http.StatusConflict, gin.H{"error": "Stok yang tersedia tidak mencukupi"})
+	case errors.Is(err, inventory.ErrReservasiTidakAktif):
+		c.JSON(http.StatusConflict, gin.H{"error": "Reservasi sudah tidak aktif atau sudah kedaluwarsa"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetLowStock godoc
+// @Summary Menampilkan komik dengan stok rendah
+// @Description Menampilkan komik yang stoknya di bawah reorder level, dimulai dari yang paling jauh di bawah reorder level
+// @Tags Admin
+// @Produce application/json
+// @Success 200 {array} models.Komik
+// @Router /admin/komik/low-stock [get]
+// @Security BearerAuth
+func (h *InventoryHandler) GetLowStock(c *gin.Context) {
+	komiks, err := inventory.LowStock(h.requestDB(c).Preload("Genres", repository.OrderGenres))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, komiks)
+}
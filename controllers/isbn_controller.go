@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"backend/isbn"
+	"backend/models"
+	"backend/repository"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ISBNLookupResponse adalah data buku dari provider beserta ID komik yang sudah memakai ISBN tersebut
+type ISBNLookupResponse struct {
+	isbn.Metadata
+	KomikID *uint `json:"komik_id,omitempty"` // Diisi jika komik dengan ISBN ini sudah ada
+}
+
+// ISBNHandler menangani pencarian data buku berdasarkan ISBN
+type ISBNHandler struct {
+	komiks   repository.KomikRepository
+	metadata isbn.MetadataProvider
+}
+
+// NewISBNHandler membuat ISBNHandler. komiks dipakai untuk mencari komik yang sudah memakai
+// ISBN yang dicari. metadata boleh nil jika pencarian data buku dinonaktifkan
+func NewISBNHandler(komiks repository.KomikRepository, metadata isbn.MetadataProvider) *ISBNHandler {
+	return &ISBNHandler{komiks: komiks, metadata: metadata}
+}
+
+// LookupISBN godoc
+// @Summary Mencari data buku berdasarkan ISBN
+// @Description Mengambil judul, author, publisher, genre dan tahun terbit dari provider metadata (Open Library) untuk mengisi form komik. Nama field sama dengan field komik
+// @Tags Komik
+// @Produce application/json
+// @Param isbn path string true "ISBN-10 atau ISBN-13, boleh dengan tanda hubung"
+// @Success 200 {object} ISBNLookupResponse
+// @Failure 400 {object} map[string]string "ISBN tidak valid"
+// @Failure 404 {object} map[string]string "Data buku tidak ditemukan"
+// @Failure 502 {object} map[string]string "Provider metadata tidak dapat dihubungi"
+// @Router /isbn/{isbn} [get]
+// @Security BearerAuth
+func (h *ISBNHandler) LookupISBN(c *gin.Context) {
+	value := isbn.Normalize(c.Param("isbn"))
+	if !isbn.Valid10(value) && !isbn.Valid13(value) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ISBN tidak valid"})
+		return
+	}
+	if h.metadata == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Pencarian data buku tidak diaktifkan"})
+		return
+	}
+
+	metadata, err := h.metadata.Lookup(c.Request.Context(), value)
+	if err != nil {
+		if errors.Is(err, isbn.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Data buku tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Gagal mengambil data buku: " + err.Error()})
+		return
+	}
+
+	// Lengkapi ISBN dari yang dicari jika provider tidak mengembalikannya
+	komik := models.Komik{ISBN10: nonEmptyString(metadata.ISBN10), ISBN13: nonEmptyString(metadata.ISBN13)}
+	if len(value) == 10 && komik.ISBN10 == nil {
+		komik.ISBN10 = &value
+	} else if len(value) == 13 && komik.ISBN13 == nil {
+		komik.ISBN13 = &value
+	}
+	komik.NormalizeISBN()
+	metadata.ISBN10 = stringValue(komik.ISBN10)
+	metadata.ISBN13 = stringValue(komik.ISBN13)
+
+	response := ISBNLookupResponse{Metadata: *metadata}
+	existing, err := h.komiks.FindByISBN(c.Request.Context(), komik.ISBN10, komik.ISBN13)
+	if err == nil {
+		response.KomikID = &existing.ID
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func nonEmptyString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,62 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend/controllers"
+	"backend/isbn"
+	"backend/isbn/isbntest"
+	"backend/models"
+)
+
+func TestLookupISBN(t *testing.T) {
+	isbn13 := "9784088725093"
+	komiks := newFakeKomiks(models.Komik{ID: 7, Nama: "One Piece", ISBN13: &isbn13})
+
+	tests := []struct {
+		name     string
+		metadata isbn.MetadataProvider
+		isbn     string
+		status   int
+		nama     string
+		komikID  uint
+	}{
+		{"ISBN-10 dilengkapi dan komik yang ada ditemukan", isbntest.NewFake(), "4-08-872509-3", http.StatusOK, "ONE PIECE 1", 7},
+		{"ISBN-13 tanpa komik", isbntest.NewFake(), "9781421528267", http.StatusOK, "Naruto, Vol. 1", 0},
+		{"checksum salah", isbntest.NewFake(), "4-08-872509-4", http.StatusBadRequest, "", 0},
+		{"tidak ada di provider", isbntest.NewFake(), "9780000000002", http.StatusNotFound, "", 0},
+		{"provider dinonaktifkan", nil, "9784088725093", http.StatusServiceUnavailable, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := controllers.NewISBNHandler(komiks, tt.metadata)
+			w := serve(http.MethodGet, "/isbn/:isbn", h.LookupISBN, "/isbn/"+tt.isbn, "", 1, 1)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var response controllers.ISBNLookupResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			if response.Nama != tt.nama {
+				t.Errorf("nama = %q, want %q", response.Nama, tt.nama)
+			}
+			if response.ISBN10 == "" || response.ISBN13 == "" {
+				t.Errorf("ISBN tidak dilengkapi: %+v", response.Metadata)
+			}
+			var komikID uint
+			if response.KomikID != nil {
+				komikID = *response.KomikID
+			}
+			if komikID != tt.komikID {
+				t.Errorf("komik_id = %d, want %d", komikID, tt.komikID)
+			}
+		})
+	}
+}
@@ -1,73 +1,41 @@
 package controllers
 
 import (
-	"backend/config"
 	"backend/models"
-	"log"
+	"backend/repository"
+	"backend/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
 )
 
-// WebSocket upgrader
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Perbolehkan semua origin (ubah sesuai kebutuhan)
-	},
+// KomikHandler menangani request data komik
+type KomikHandler struct {
+	komiks *services.KomikService
 }
 
-// WebSocket clients map
-var clients = make(map[*websocket.Conn]bool)
-var broadcast = make(chan models.Komik)
-
-// HandleWebSocket godoc
-// @Summary Mengelola koneksi WebSocket
-// @Description Menyediakan koneksi WebSocket untuk memperbarui stok komik secara real-time
-// @Tags WebSocket
-// @Produce application/json
-// @Success 200 {string} string "Client terhubung"
-// @Router /komik/updates [get]
-func HandleWebSocket(c *gin.Context) {
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		log.Printf("Gagal meng-upgrade ke WebSocket: %v", err)
-		return
-	}
-	defer conn.Close()
-
-	clients[conn] = true
-	defer delete(clients, conn)
-
-	// Dengarkan pembaruan stok dari channel broadcast
-	for {
-		komik := <-broadcast
-		for client := range clients {
-			err := client.WriteJSON(komik)
-			if err != nil {
-				log.Printf("Gagal mengirim data ke WebSocket: %v", err)
-				client.Close()
-				delete(clients, client)
-			}
-		}
-	}
-}
-
-// Broadcast stok komik terbaru
-func BroadcastStokUpdate(komik models.Komik) {
-	broadcast <- komik
+// NewKomikHandler membuat KomikHandler
+func NewKomikHandler(komiks *services.KomikService) *KomikHandler {
+	return &KomikHandler{komiks: komiks}
 }
 
 // GetKomik godoc
 // @Summary Menampilkan semua data komik
-// @Description Mengambil semua data komik dari database
+// @Description Mengambil semua data komik dari database, dapat difilter berdasarkan author, publisher dan genre
 // @Tags Komik
 // @Produce application/json
+// @Param author_id query int false "Filter ID Author"
+// @Param publisher_id query int false "Filter ID Publisher"
+// @Param genre_id query int false "Filter ID Genre"
 // @Success 200 {array} models.Komik
 // @Router /komik [get]
-func GetKomik(c *gin.Context) {
-	var komik []models.Komik
-	if err := config.DB.Find(&komik).Error; err != nil {
+func (h *KomikHandler) GetKomik(c *gin.Context) {
+	komik, err := h.komiks.List(c.Request.Context(), repository.KomikFilter{
+		AuthorID:    c.Query("author_id"),
+		PublisherID: c.Query("publisher_id"),
+		GenreID:     c.Query("genre_id"),
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -76,20 +44,25 @@ func GetKomik(c *gin.Context) {
 
 // CreateKomik godoc
 // @Summary Menambahkan komik baru
-// @Description Membuat data komik baru di database
+// @Description Membuat data komik baru di database. Author, publisher dan genre dapat dipilih dengan author_id, publisher_id dan genre_ids, atau dikirim sebagai teks dan akan dicari atau dibuat di katalog
 // @Tags Komik
 // @Accept application/json
 // @Produce application/json
 // @Param data body models.Komik true "Data Komik"
 // @Success 201 {object} models.Komik
+// @Failure 409 {object} map[string]string "ISBN sudah dipakai oleh komik lain"
 // @Router /komik [post]
-func CreateKomik(c *gin.Context) {
+func (h *KomikHandler) CreateKomik(c *gin.Context) {
 	var komik models.Komik
 	if err := c.ShouldBindJSON(&komik); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
-	config.DB.Create(&komik)
+	if err := h.komiks.Create(c.Request.Context(), &komik, currentActor(c)); err != nil {
+		respondWriteError(c, err)
+		return
+	}
+	setETag(c, komik.Version)
 	c.JSON(http.StatusCreated, komik)
 }
 
@@ -100,39 +73,133 @@ func CreateKomik(c *gin.Context) {
 // @Produce application/json
 // @Param id path int true "ID Komik"
 // @Success 200 {object} models.Komik
+// @Header 200 {string} ETag "Versi data komik"
 // @Router /komik/{id} [get]
-func GetKomikByID(c *gin.Context) {
-	id := c.Param("id")
-	var komik models.Komik
-	if err := config.DB.First(&komik, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+func (h *KomikHandler) GetKomikByID(c *gin.Context) {
+	komik, err := h.komiks.Get(c.Request.Context(), paramID(c, "id"))
+	if err != nil {
+		respondServiceError(c, err)
 		return
 	}
+	setETag(c, komik.Version)
 	c.JSON(http.StatusOK, komik)
 }
 
+// KomikInput adalah data lengkap komik yang dikirim saat PUT. Semua field wajib dikirim
+// karena PUT mengganti seluruh data komik. Teks author, publisher dan genre boleh tidak
+// dikirim jika author_id, publisher_id atau genre_ids dikirim
+type KomikInput struct {
+	Nama         *string `json:"nama" binding:"required"`
+	ISBN10       *string `json:"isbn10"` // Boleh tidak dikirim, dilengkapi dari isbn13 jika memungkinkan
+	ISBN13       *string `json:"isbn13"`
+	Author       *string `json:"author" binding:"required_without=AuthorID"`
+	Genre        *string `json:"genre" binding:"required_without=GenreIDs"`
+	TahunTerbit  *int    `json:"tahun_terbit" binding:"required"`
+	Publisher    *string `json:"publisher" binding:"required_without=PublisherID"`
+	Stok         *int    `json:"stok" binding:"required"`
+	ReorderLevel *int    `json:"reorder_level"` // Default 0 (tanpa peringatan stok rendah) jika tidak dikirim
+	Harga        *int64  `json:"harga" binding:"required"`
+	MataUang     *string `json:"mata_uang"` // Default IDR jika tidak dikirim
+	AuthorID     *uint   `json:"author_id"`
+	PublisherID  *uint   `json:"publisher_id"`
+	GenreIDs     []uint  `json:"genre_ids"`
+}
+
+// komikPatchFields adalah field komik yang dapat diubah lewat PATCH
+var komikPatchFields = []string{
+	"nama", "isbn10", "isbn13", "author", "genre", "tahun_terbit", "publisher", "stok", "reorder_level", "harga", "mata_uang",
+	"author_id", "publisher_id", "genre_ids",
+}
+
 // UpdateKomik godoc
-// @Summary Memperbarui data komik
-// @Description Memperbarui data komik berdasarkan ID
+// @Summary Mengganti data komik
+// @Description Mengganti seluruh data komik berdasarkan ID. Semua field wajib dikirim
 // @Tags Komik
 // @Accept application/json
 // @Produce application/json
 // @Param id path int true "ID Komik"
-// @Param data body models.Komik true "Data Komik yang Diperbarui"
+// @Param If-Match header string true "ETag dari data komik yang akan diubah"
+// @Param data body KomikInput true "Data Komik yang Diperbarui"
 // @Success 200 {object} models.Komik
+// @Failure 409 {object} map[string]string "ISBN sudah dipakai oleh komik lain"
+// @Failure 412 {object} map[string]string "Data sudah diubah oleh pengguna lain"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
 // @Router /komik/{id} [put]
-func UpdateKomik(c *gin.Context) {
-	id := c.Param("id")
-	var komik models.Komik
-	if err := config.DB.First(&komik, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+func (h *KomikHandler) UpdateKomik(c *gin.Context) {
+	komik, err := h.komiks.Find(c.Request.Context(), paramID(c, "id"))
+	if err != nil {
+		respondServiceError(c, err)
 		return
 	}
-	if err := c.ShouldBindJSON(&komik); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !checkIfMatch(c, komik.Version) {
 		return
 	}
-	config.DB.Save(&komik)
+
+	var input KomikInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	komik.Nama = *input.Nama
+	komik.ISBN10 = input.ISBN10
+	komik.ISBN13 = input.ISBN13
+	komik.Author = stringValue(input.Author)
+	komik.Genre = stringValue(input.Genre)
+	komik.TahunTerbit = *input.TahunTerbit
+	komik.Publisher = stringValue(input.Publisher)
+	komik.Stok = *input.Stok
+	komik.ReorderLevel = intValue(input.ReorderLevel)
+	komik.Harga = *input.Harga
+	komik.MataUang = stringValue(input.MataUang)
+	komik.AuthorID = input.AuthorID
+	komik.PublisherID = input.PublisherID
+	komik.GenreIDs = input.GenreIDs
+
+	h.saveKomik(c, &komik, nil)
+}
+
+// PatchKomik godoc
+// @Summary Memperbarui sebagian data komik
+// @Description Memperbarui data komik menggunakan JSON Merge Patch (RFC 7396). Field yang tidak dikirim tidak berubah, field bernilai null dikosongkan
+// @Tags Komik
+// @Accept application/merge-patch+json
+// @Produce application/json
+// @Param id path int true "ID Komik"
+// @Param If-Match header string true "ETag dari data komik yang akan diubah"
+// @Param data body object true "Field komik yang diubah (nama, isbn10, isbn13, author, genre, tahun_terbit, publisher, stok, reorder_level, harga, mata_uang, author_id, publisher_id, genre_ids)"
+// @Success 200 {object} models.Komik
+// @Failure 409 {object} map[string]string "ISBN sudah dipakai oleh komik lain"
+// @Failure 412 {object} map[string]string "Data sudah diubah oleh pengguna lain"
+// @Failure 415 {object} map[string]string "Content-Type tidak didukung"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
+// @Router /komik/{id} [patch]
+func (h *KomikHandler) PatchKomik(c *gin.Context) {
+	komik, err := h.komiks.Find(c.Request.Context(), paramID(c, "id"))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	if !checkIfMatch(c, komik.Version) {
+		return
+	}
+
+	previous := komik
+	if err := applyMergePatch(c, &komik, komikPatchFields...); err != nil {
+		respondPatchError(c, err)
+		return
+	}
+
+	h.saveKomik(c, &komik, &previous)
+}
+
+// saveKomik menyimpan perubahan komik dengan pengecekan versi lalu mengirim response.
+// previous adalah data komik sebelum diubah, nil jika seluruh data diganti
+func (h *KomikHandler) saveKomik(c *gin.Context, komik *models.Komik, previous *models.Komik) {
+	if err := h.komiks.Update(c.Request.Context(), komik, previous, currentActor(c)); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	setETag(c, komik.Version)
 	c.JSON(http.StatusOK, komik)
 }
 
@@ -141,15 +208,37 @@ func UpdateKomik(c *gin.Context) {
 // @Description Menghapus data komik berdasarkan ID
 // @Tags Komik
 // @Param id path int true "ID Komik"
+// @Param If-Match header string true "ETag dari data komik yang akan dihapus"
 // @Success 200 {string} string "Data berhasil dihapus"
+// @Failure 412 {object} map[string]string "Data sudah diubah oleh pengguna lain"
+// @Failure 428 {object} map[string]string "Header If-Match tidak dikirim"
 // @Router /komik/{id} [delete]
-func DeleteKomik(c *gin.Context) {
-	id := c.Param("id")
-	var komik models.Komik
-	if err := config.DB.First(&komik, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Data tidak ditemukan"})
+func (h *KomikHandler) DeleteKomik(c *gin.Context) {
+	komik, err := h.komiks.Find(c.Request.Context(), paramID(c, "id"))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	if !checkIfMatch(c, komik.Version) {
+		return
+	}
+	if err := h.komiks.Delete(c.Request.Context(), &komik, currentActor(c)); err != nil {
+		respondWriteError(c, err)
 		return
 	}
-	config.DB.Delete(&komik)
 	c.JSON(http.StatusOK, gin.H{"message": "Data berhasil dihapus"})
 }
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func intValue(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
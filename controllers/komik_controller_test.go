@@ -0,0 +1,176 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend/controllers"
+	"backend/models"
+	"backend/services"
+)
+
+func newKomikHandler(komiks *fakeKomiks) *controllers.KomikHandler {
+	return controllers.NewKomikHandler(services.NewKomikService(komiks, nil))
+}
+
+func TestGetKomik(t *testing.T) {
+	komiks := newFakeKomiks(models.Komik{ID: 1, Nama: "One Piece", Version: 1}, models.Komik{ID: 2, Nama: "Naruto", Version: 1})
+	h := newKomikHandler(komiks)
+
+	w := serve(http.MethodGet, "/komik", h.GetKomik, "/komik", "", 0, 0)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var komik []models.Komik
+	if err := json.Unmarshal(w.Body.Bytes(), &komik); err != nil {
+		t.Fatal(err)
+	}
+	if len(komik) != 2 || komik[0].Nama != "One Piece" || komik[1].Nama != "Naruto" {
+		t.Errorf("komik = %+v", komik)
+	}
+
+	w = serve(http.MethodGet, "/komik/:id", h.GetKomikByID, "/komik/3", "", 0, 0)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("komik yang tidak ada: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestCreateKomik(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"data lengkap", `{"nama":"One Piece","author":"Eiichiro Oda","genre":"Aksi","tahun_terbit":1997,"publisher":"Shueisha","stok":5,"harga":45000}`, http.StatusCreated},
+		{"nama kosong", `{"nama":"  ","tahun_terbit":1997,"stok":5,"harga":45000}`, http.StatusBadRequest},
+		{"stok negatif", `{"nama":"One Piece","tahun_terbit":1997,"stok":-1,"harga":45000}`, http.StatusBadRequest},
+		{"JSON rusak", `{"nama":`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			komiks := newFakeKomiks()
+			h := newKomikHandler(komiks)
+			w := serve(http.MethodPost, "/komik", h.CreateKomik, "/komik", tt.body, 1, 1)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusCreated {
+				if len(komiks.komik) != 0 {
+					t.Errorf("komik tetap disimpan: %+v", komiks.komik)
+				}
+				return
+			}
+			if got := w.Header().Get("ETag"); got != `"1"` {
+				t.Errorf("ETag = %q, want %q", got, `"1"`)
+			}
+			if k := komiks.komik[1]; k.Nama != "One Piece" || k.Version != 1 || k.MataUang != "IDR" {
+				t.Errorf("komik tersimpan = %+v", k)
+			}
+		})
+	}
+}
+
+func TestUpdateKomik(t *testing.T) {
+	body := `{"nama":"One Piece 2","author":"Eiichiro Oda","genre":"Aksi","tahun_terbit":1998,"publisher":"Shueisha","stok":3,"harga":45000}`
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		ifMatch string
+		status  int
+		nama    string
+	}{
+		{"versi sesuai", "/komik/1", body, `"2"`, http.StatusOK, "One Piece 2"},
+		{"If-Match wildcard", "/komik/1", body, "*", http.StatusOK, "One Piece 2"},
+		{"tanpa If-Match", "/komik/1", body, "", http.StatusPreconditionRequired, "One Piece"},
+		{"versi lama", "/komik/1", body, `"1"`, http.StatusPreconditionFailed, "One Piece"},
+		{"field wajib tidak dikirim", "/komik/1", `{"nama":"One Piece 2"}`, `"2"`, http.StatusBadRequest, "One Piece"},
+		{"komik tidak ada", "/komik/9", body, `"1"`, http.StatusNotFound, "One Piece"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			komiks := newFakeKomiks(models.Komik{ID: 1, Nama: "One Piece", TahunTerbit: 1997, Version: 2})
+			h := newKomikHandler(komiks)
+			var headers []string
+			if tt.ifMatch != "" {
+				headers = []string{"If-Match", tt.ifMatch}
+			}
+			w := serve(http.MethodPut, "/komik/:id", h.UpdateKomik, tt.path, tt.body, 1, 1, headers...)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if k := komiks.komik[1]; k.Nama != tt.nama {
+				t.Errorf("nama = %q, want %q", k.Nama, tt.nama)
+			}
+			if tt.status == http.StatusOK {
+				if got := w.Header().Get("ETag"); got != `"3"` {
+					t.Errorf("ETag = %q, want %q", got, `"3"`)
+				}
+			}
+			if tt.status == http.StatusPreconditionFailed {
+				if got := w.Header().Get("ETag"); got != `"2"` {
+					t.Errorf("ETag = %q, want versi saat ini %q", got, `"2"`)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchKomik(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		stok        int
+	}{
+		{"merge patch", "application/merge-patch+json", `{"stok":7}`, http.StatusOK, 7},
+		{"content type form", "application/x-www-form-urlencoded", `{"stok":7}`, http.StatusUnsupportedMediaType, 4},
+		{"stok negatif", "application/merge-patch+json", `{"stok":-1}`, http.StatusBadRequest, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			komiks := newFakeKomiks(models.Komik{ID: 1, Nama: "One Piece", TahunTerbit: 1997, Stok: 4, MataUang: "IDR", Version: 1})
+			h := newKomikHandler(komiks)
+			w := serve(http.MethodPatch, "/komik/:id", h.PatchKomik, "/komik/1", tt.body, 1, 1,
+				"Content-Type", tt.contentType, "If-Match", `"1"`)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if k := komiks.komik[1]; k.Stok != tt.stok || k.Nama != "One Piece" {
+				t.Errorf("komik = %+v, want stok %d", k, tt.stok)
+			}
+		})
+	}
+}
+
+func TestDeleteKomik(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		status  int
+		dihapus bool
+	}{
+		{"versi sesuai", `"1"`, http.StatusOK, true},
+		{"tanpa If-Match", "", http.StatusPreconditionRequired, false},
+		{"versi lama", `"0"`, http.StatusPreconditionFailed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			komiks := newFakeKomiks(models.Komik{ID: 1, Nama: "One Piece", Version: 1})
+			h := newKomikHandler(komiks)
+			var headers []string
+			if tt.ifMatch != "" {
+				headers = []string{"If-Match", tt.ifMatch}
+			}
+			w := serve(http.MethodDelete, "/komik/:id", h.DeleteKomik, "/komik/1", "", 1, 1, headers...)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if _, ada := komiks.komik[1]; ada == tt.dihapus {
+				t.Errorf("komik masih ada = %v, want %v", ada, !tt.dihapus)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errUnsupportedPatch dikembalikan jika Content-Type request bukan JSON Merge Patch
+var errUnsupportedPatch = errors.New("Content-Type harus application/merge-patch+json")
+
+// mergePatch menerapkan patch pada target sesuai algoritma JSON Merge Patch (RFC 7396)
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// applyMergePatch membaca body JSON Merge Patch dari request dan menerapkannya pada value
+// (pointer ke struct). Hanya field dengan nama JSON yang terdapat di allowed yang boleh diubah,
+// field yang bernilai null dikosongkan
+func applyMergePatch(c *gin.Context, value interface{}, allowed ...string) error {
+	contentType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if contentType != "application/merge-patch+json" && contentType != "application/json" {
+		return errUnsupportedPatch
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+
+	var patch interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&patch); err != nil {
+		return fmt.Errorf("body bukan JSON yang valid: %w", err)
+	}
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return errors.New("body harus berupa objek JSON")
+	}
+
+	whitelist := make(map[string]bool, len(allowed))
+	for _, field := range allowed {
+		whitelist[field] = true
+	}
+	for key := range patchObject {
+		if !whitelist[key] {
+			return fmt.Errorf("field %s tidak dapat diubah", key)
+		}
+	}
+
+	// Terapkan patch pada representasi JSON dokumen saat ini
+	current, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var document interface{}
+	if err := json.Unmarshal(current, &document); err != nil {
+		return err
+	}
+	merged, err := json.Marshal(mergePatch(document, patchObject))
+	if err != nil {
+		return err
+	}
+
+	// Decode ke struct baru agar field yang dihapus oleh patch kembali ke nilai kosong,
+	// lalu salin hanya field yang disebut di patch ke value
+	target := reflect.ValueOf(value).Elem()
+	patched := reflect.New(target.Type())
+	if err := json.Unmarshal(merged, patched.Interface()); err != nil {
+		return err
+	}
+	for key := range patchObject {
+		index := jsonFieldIndex(target.Type(), key)
+		if index < 0 {
+			return fmt.Errorf("field %s tidak dikenal", key)
+		}
+		target.Field(index).Set(patched.Elem().Field(index))
+	}
+	return nil
+}
+
+// jsonFieldIndex mencari indeks field struct berdasarkan nama pada tag json
+func jsonFieldIndex(t reflect.Type, name string) int {
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// respondPatchError mengirim response 415 untuk Content-Type yang salah dan 400 untuk error lainnya
+func respondPatchError(c *gin.Context, err error) {
+	if errors.Is(err, errUnsupportedPatch) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"backend/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// NotificationHandler menangani request notifikasi user
+type NotificationHandler struct {
+	database
+}
+
+// NewNotificationHandler membuat NotificationHandler. readDB dipakai untuk query baca dari request GET
+func NewNotificationHandler(db, readDB *gorm.DB) *NotificationHandler {
+	return &NotificationHandler{database{db: db, readDB: readDB}}
+}
+
+// GetNotifications godoc
+// @Summary Menampilkan kotak masuk notifikasi
+// @Description Menampilkan notifikasi user yang login dari yang terbaru: komik di wishlist yang tersedia kembali (back_in_stock), balasan komentar (comment_reply), perubahan status pesanan (order_status) dan hasil moderasi komentar (moderation)
+// @Tags Notifikasi
+// @Produce application/json
+// @Param belum_dibaca query bool false "Hanya notifikasi yang belum dibaca"
+// @Param limit query int false "Jumlah notifikasi (default 50, maksimal 500)"
+// @Param offset query int false "Jumlah notifikasi yang dilewati"
+// @Success 200 {array} models.Notification
+// @Router /notifications [get]
+// @Security BearerAuth
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	query := h.requestDB(c).Where("user_id = ?", c.MustGet("user_id"))
+	if c.Query("belum_dibaca") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+
+	notifications := []models.Notification{}
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&notifications).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead godoc
+// @Summary Menandai notifikasi sudah dibaca
+// @Description Waktu dibaca tidak berubah jika notifikasi sudah pernah ditandai
+// @Tags Notifikasi
+// @Produce application/json
+// @Param id path int true "ID Notifikasi"
+// @Success 200 {object} models.Notification
+// @Router /notifications/{id}/read [post]
+// @Security BearerAuth
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	var notification models.Notification
+	if err := h.requestDB(c).Where("user_id = ?", c.MustGet("user_id")).First(&notification, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notifikasi tidak ditemukan"})
+		return
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		if err := h.requestDB(c).Model(&notification).Update("read_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		notification.ReadAt = &now
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Menandai semua notifikasi sudah dibaca
+// @Tags Notifikasi
+// @Produce application/json
+// @Success 200 {object} map[string]interface{} "Jumlah notifikasi yang ditandai"
+// @Router /notifications/read-all [post]
+// @Security BearerAuth
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	result := h.requestDB(c).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", c.MustGet("user_id")).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Semua notifikasi ditandai sudah dibaca", "jumlah": result.RowsAffected})
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"backend/models"
+	"backend/pricing"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PricingHandler menangani request harga dan diskon
+type PricingHandler struct {
+	database
+}
+
+// NewPricingHandler membuat PricingHandler. readDB dipakai untuk query baca dari request GET
+func NewPricingHandler(db, readDB *gorm.DB) *PricingHandler {
+	return &PricingHandler{database{db: db, readDB: readDB}}
+}
+
+// QuoteInput adalah daftar komik yang ingin dihitung harganya
+type QuoteInput struct {
+	Items []pricing.Item `json:"items" binding:"required,min=1,dive"`
+}
+
+// GetQuote godoc
+// @Summary Menghitung harga komik
+// @Description Menghitung harga setiap komik beserta diskon yang berlaku saat ini. Satu baris hanya memakai satu diskon dengan potongan terbesar. Semua harga dalam satuan terkecil mata uang dan total dihitung per mata uang
+// @Tags Harga
+// @Accept application/json
+// @Produce application/json
+// @Param data body QuoteInput true "Komik dan jumlah yang dibeli"
+// @Success 200 {object} pricing.Penawaran
+// @Failure 400 {object} map[string]string "Jumlah satu komik lebih dari 10000 atau total harga terlalu besar"
+// @Failure 404 {object} map[string]string "Komik tidak ditemukan"
+// @Router /pricing/quote [post]
+// @Security BearerAuth
+func (h *PricingHandler) GetQuote(c *gin.Context) {
+	var input QuoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	penawaran, err := pricing.Quote(h.requestDB(c), input.Items, time.Now())
+	if err != nil {
+		if errors.Is(err, pricing.ErrKomikTidakDitemukan) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, pricing.ErrJumlahTerlaluBanyak) || errors.Is(err, pricing.ErrHargaTerlaluBesar) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, penawaran)
+}
+
+// GetDiscounts godoc
+// @Summary Menampilkan semua diskon
+// @Tags Harga
+// @Produce application/json
+// @Param aktif query bool false "Hanya diskon yang sedang berlaku"
+// @Success 200 {array} models.Diskon
+// @Router /discounts [get]
+// @Security BearerAuth
+func (h *PricingHandler) GetDiscounts(c *gin.Context) {
+	var diskon []models.Diskon
+	var err error
+	if c.Query("aktif") == "true" {
+		diskon, err = pricing.ActiveDiscounts(h.requestDB(c), time.Now())
+	} else {
+		err = h.requestDB(c).Order("mulai DESC").Find(&diskon).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, diskon)
+}
+
+// GetDiscountByID godoc
+// @Summary Menampilkan detail diskon
+// @Tags Harga
+// @Produce application/json
+// @Param id path int true "ID Diskon"
+// @Success 200 {object} models.Diskon
+// @Router /discounts/{id} [get]
+// @Security BearerAuth
+func (h *PricingHandler) GetDiscountByID(c *gin.Context) {
+	var diskon models.Diskon
+	if err := h.requestDB(c).First(&diskon, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Diskon tidak ditemukan"})
+		return
+	}
+	c.JSON(http.StatusOK, diskon)
+}
+
+// CreateDiscount godoc
+// @Summary Menambahkan diskon baru
+// @Description Diskon berjenis persen (nilai 1-100) atau nominal (potongan dalam satuan terkecil mata uang) untuk tepat satu dari komik_id, genre_id atau publisher_id, berlaku dari mulai sampai sebelum berakhir
+// @Tags Harga
+// @Accept application/json
+// @Produce application/json
+// @Param data body models.Diskon true "Data Diskon"
+// @Success 201 {object} models.Diskon
+// @Router /discounts [post]
+// @Security BearerAuth
+func (h *PricingHandler) CreateDiscount(c *gin.Context) {
+	var diskon models.Diskon
+	if err := c.ShouldBindJSON(&diskon); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	diskon.ID = 0
+	if !h.checkDiskonTarget(c, &diskon) {
+		return
+	}
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&diskon).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, "diskon", diskon.ID, nil, diskon)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, diskon)
+}
+
+// UpdateDiscount godoc
+// @Summary Mengganti data diskon
+// @Tags Harga
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Diskon"
+// @Param data body models.Diskon true "Data Diskon"
+// @Success 200 {object} models.Diskon
+// @Router /discounts/{id} [put]
+// @Security BearerAuth
+func (h *PricingHandler) UpdateDiscount(c *gin.Context) {
+	var existing models.Diskon
+	if err := h.requestDB(c).First(&existing, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Diskon tidak ditemukan"})
+		return
+	}
+
+	var diskon models.Diskon
+	if err := c.ShouldBindJSON(&diskon); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	diskon.ID = existing.ID
+	diskon.CreatedAt = existing.CreatedAt
+	if !h.checkDiskonTarget(c, &diskon) {
+		return
+	}
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("*").Save(&diskon).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditUpdate, "diskon", diskon.ID, existing, diskon)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, diskon)
+}
+
+// DeleteDiscount godoc
+// @Summary Menghapus diskon
+// @Tags Harga
+// @Param id path int true "ID Diskon"
+// @Success 200 {string} string "Diskon berhasil dihapus"
+// @Router /discounts/{id} [delete]
+// @Security BearerAuth
+func (h *PricingHandler) DeleteDiscount(c *gin.Context) {
+	var diskon models.Diskon
+	if err := h.requestDB(c).First(&diskon, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Diskon tidak ditemukan"})
+		return
+	}
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&diskon).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditDelete, "diskon", diskon.ID, diskon, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Diskon berhasil dihapus"})
+}
+
+// checkDiskonTarget memastikan komik, genre atau publisher tujuan diskon ada dan mengisi
+// mata uang diskon nominal. Jika tidak valid, response 400 langsung dikirim
+func (h *PricingHandler) checkDiskonTarget(c *gin.Context, diskon *models.Diskon) bool {
+	if diskon.Jenis == models.DiskonNominal {
+		diskon.MataUang = pricing.MataUang(diskon.MataUang)
+	} else {
+		diskon.MataUang = ""
+	}
+
+	var target interface{}
+	var id uint
+	switch {
+	case diskon.KomikID != nil:
+		target, id = &models.Komik{}, *diskon.KomikID
+	case diskon.GenreID != nil:
+		target, id = &models.Genre{}, *diskon.GenreID
+	default:
+		target, id = &models.Publisher{}, *diskon.PublisherID
+	}
+
+	var count int64
+	if err := h.requestDB(c).Model(target).Where("id = ?", id).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if count == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Komik, genre atau publisher tujuan diskon tidak ditemukan"})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"backend/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ToggleReaction godoc
+// @Summary Memberi atau membatalkan reaksi pada komentar
+// @Description Jika user belum memberi reaksi tersebut maka reaksi ditambahkan, jika sudah maka reaksi dibatalkan
+// @Tags Komentar
+// @Produce application/json
+// @Param id path int true "ID Komentar"
+// @Param reaksi path string true "Jenis reaksi" Enums(like, love, haha, wow, sad, angry)
+// @Success 200 {object} models.Comment
+// @Router /comments/{id}/reactions/{reaksi} [post]
+// @Security BearerAuth
+func (h *CommentHandler) ToggleReaction(c *gin.Context) {
+	comment, err := h.comments.ToggleReaction(c.Request.Context(), paramID(c, "id"), c.Param("reaksi"), currentActor(c))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// GetKomikComments godoc
+// @Summary Menampilkan komentar pada sebuah komik
+// @Description Mengambil semua komentar untuk komik tertentu beserta jumlah reaksinya. Gunakan sort=top untuk mengurutkan berdasarkan jumlah reaksi terbanyak
+// @Tags Komentar
+// @Produce application/json
+// @Param id path int true "ID Komik"
+// @Param sort query string false "Urutan komentar" Enums(terbaru, top)
+// @Success 200 {array} models.Comment
+// @Router /komik/{id}/comments [get]
+// @Security BearerAuth
+func (h *CommentHandler) GetKomikComments(c *gin.Context) {
+	comments, err := h.comments.ListByKomik(c.Request.Context(), paramID(c, "id"), c.DefaultQuery("sort", repository.UrutanTerbaru), currentActor(c))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comments)
+}
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"backend/inventory"
+	"backend/models"
+	"backend/notification"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReservationHandler menangani request reservasi stok
+type ReservationHandler struct {
+	database
+	ttl time.Duration // Lama stok ditahan untuk setiap reservasi
+}
+
+// NewReservationHandler membuat ReservationHandler yang menahan stok selama ttl. readDB dipakai
+// untuk query baca dari request GET
+func NewReservationHandler(db, readDB *gorm.DB, ttl time.Duration) *ReservationHandler {
+	return &ReservationHandler{database: database{db: db, readDB: readDB}, ttl: ttl}
+}
+
+// ReservationInput adalah permintaan untuk menahan stok komik selama checkout
+type ReservationInput struct {
+	KomikID uint `json:"komik_id" binding:"required"`
+	Jumlah  int  `json:"jumlah" binding:"required,min=1"`
+}
+
+// ConfirmReservationInput adalah konfirmasi pesanan dari reservasi stok
+type ConfirmReservationInput struct {
+	Referensi      string `json:"referensi" binding:"max=255"` // Nomor pesanan, default "reservasi <id>"
+	ReservationIDs []uint `json:"reservation_ids"`             // Kosongkan untuk mengonfirmasi semua reservasi aktif milik user
+}
+
+// GetReservations godoc
+// @Summary Menampilkan reservasi stok
+// @Description Menampilkan reservasi milik user yang login dari yang terbaru. Admin dapat melihat reservasi semua user
+// @Tags Reservasi
+// @Produce application/json
+// @Param status query string false "Filter status (aktif, dikonfirmasi, dilepas, kedaluwarsa)"
+// @Success 200 {array} models.Reservation
+// @Router /reservations [get]
+// @Security BearerAuth
+func (h *ReservationHandler) GetReservations(c *gin.Context) {
+	query := h.reservationScope(c)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	reservations := []models.Reservation{}
+	if err := query.Order("created_at DESC, id DESC").Find(&reservations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reservations)
+}
+
+// CreateReservation godoc
+// @Summary Menahan stok komik selama checkout
+// @Description Menahan stok komik untuk user yang login selama waktu tertentu (default 15 menit). Stok yang ditahan tidak dapat dipesan atau dibeli user lain sampai reservasi dikonfirmasi, dilepas atau kedaluwarsa. Jika user sudah memiliki reservasi aktif untuk komik yang sama, jumlahnya diganti dan waktunya diperpanjang
+// @Tags Reservasi
+// @Accept application/json
+// @Produce application/json
+// @Param data body ReservationInput true "Komik dan jumlah yang ditahan"
+// @Success 201 {object} models.Reservation
+// @Failure 404 {object} map[string]string "Komik tidak ditemukan"
+// @Failure 409 {object} map[string]string "Stok yang tersedia tidak mencukupi"
+// @Router /reservations [post]
+// @Security BearerAuth
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	var input ReservationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	var reservation *models.Reservation
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		reservation, err = inventory.Reserve(tx, userID, input.KomikID, input.Jumlah, h.ttl, time.Now())
+		return err
+	})
+	if err != nil {
+		respondStockError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// DeleteReservation godoc
+// @Summary Melepas reservasi stok
+// @Description Membatalkan reservasi aktif sehingga stoknya dapat dipesan user lain. Hanya pemilik reservasi atau admin; jika dibatalkan admin, pemiliknya mendapat notifikasi
+// @Tags Reservasi
+// @Param id path int true "ID Reservasi"
+// @Success 200 {object} models.Reservation
+// @Failure 409 {object} map[string]string "Reservasi sudah tidak aktif"
+// @Router /reservations/{id} [delete]
+// @Security BearerAuth
+func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
+	var reservation models.Reservation
+	if err := h.reservationScope(c).First(&reservation, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reservasi tidak ditemukan"})
+		return
+	}
+
+	before := reservation
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := inventory.Release(tx, &reservation, models.ReservasiDilepas); err != nil {
+			return err
+		}
+		// Pemilik diberi tahu jika reservasinya dibatalkan oleh admin
+		if reservation.UserID == c.MustGet("user_id").(uint) {
+			return nil
+		}
+		if err := recordAudit(c, tx, models.AuditUpdate, "reservation", reservation.ID, before, reservation); err != nil {
+			return err
+		}
+		return notification.NotifyOrderStatus(tx, reservation)
+	})
+	if err != nil {
+		respondStockError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, reservation)
+}
+
+// ConfirmReservations godoc
+// @Summary Mengonfirmasi pesanan dari reservasi stok
+// @Description Mengubah reservasi aktif milik user menjadi penjualan: stok komik dikurangi dan dicatat sebagai sale dengan referensi pesanan. Semua reservasi dikonfirmasi dalam satu transaksi; jika salah satu gagal tidak ada yang dikonfirmasi. User mendapat notifikasi order_status untuk setiap reservasi
+// @Tags Reservasi
+// @Accept application/json
+// @Produce application/json
+// @Param data body ConfirmReservationInput true "Referensi pesanan dan reservasi yang dikonfirmasi"
+// @Success 200 {array} models.Reservation
+// @Failure 404 {object} map[string]string "Reservasi tidak ditemukan"
+// @Failure 409 {object} map[string]string "Reservasi sudah tidak aktif atau stok tidak mencukupi"
+// @Router /reservations/confirm [post]
+// @Security BearerAuth
+func (h *ReservationHandler) ConfirmReservations(c *gin.Context) {
+	var input ConfirmReservationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	now := time.Now()
+	var reservations []models.Reservation
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		// Reservasi orang lain tidak dapat dikonfirmasi, admin hanya mengonfirmasi miliknya sendiri
+		query := tx.Where("user_id = ?", c.MustGet("user_id"))
+		if len(input.ReservationIDs) > 0 {
+			query = query.Where("id IN ?", input.ReservationIDs)
+		} else {
+			query = query.Where("status = ? AND berakhir > ?", models.ReservasiAktif, now)
+		}
+		if err := query.Order("id").Find(&reservations).Error; err != nil {
+			return err
+		}
+		if len(reservations) == 0 || (len(input.ReservationIDs) > 0 && len(reservations) != len(uniqueIDs(input.ReservationIDs))) {
+			return errReservasiTidakDitemukan
+		}
+
+		for i := range reservations {
+			referensi := input.Referensi
+			if referensi == "" {
+				referensi = fmt.Sprintf("reservasi %d", reservations[i].ID)
+			}
+			if err := inventory.Confirm(tx, &reservations[i], referensi, now); err != nil {
+				return err
+			}
+		}
+		return notification.NotifyOrderStatus(tx, reservations...)
+	})
+	if errors.Is(err, errReservasiTidakDitemukan) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reservasi tidak ditemukan"})
+		return
+	}
+	if err != nil {
+		respondStockError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, reservations)
+}
+
+var errReservasiTidakDitemukan = errors.New("reservasi tidak ditemukan")
+
+// reservationScope membatasi query reservasi ke milik user yang login, kecuali untuk admin
+func (h *ReservationHandler) reservationScope(c *gin.Context) *gorm.DB {
+	if role, _ := c.Get("role_id"); role == 1 {
+		return h.requestDB(c)
+	}
+	return h.requestDB(c).Where("user_id = ?", c.MustGet("user_id"))
+}
+
+func uniqueIDs(ids []uint) map[uint]bool {
+	unique := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		unique[id] = true
+	}
+	return unique
+}
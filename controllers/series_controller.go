@@ -0,0 +1,369 @@
+package controllers
+
+import (
+	"backend/models"
+	"backend/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SeriesHandler menangani request series beserta volume dan chapter-nya
+type SeriesHandler struct {
+	database
+}
+
+// NewSeriesHandler membuat SeriesHandler. readDB dipakai untuk query baca dari request GET
+func NewSeriesHandler(db, readDB *gorm.DB) *SeriesHandler {
+	return &SeriesHandler{database{db: db, readDB: readDB}}
+}
+
+// GetSeries godoc
+// @Summary Menampilkan semua series
+// @Tags Series
+// @Produce application/json
+// @Success 200 {array} models.Series
+// @Router /series [get]
+// @Security BearerAuth
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	var series []models.Series
+	if err := h.requestDB(c).Order("nama").Find(&series).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, series)
+}
+
+// GetSeriesByID godoc
+// @Summary Menampilkan detail series beserta volumenya
+// @Description Menampilkan series dengan daftar volume yang diurutkan berdasarkan nomor, lengkap dengan komik, stok dan chapter setiap volume
+// @Tags Series
+// @Produce application/json
+// @Param id path int true "ID Series"
+// @Success 200 {object} models.Series
+// @Router /series/{id} [get]
+// @Security BearerAuth
+func (h *SeriesHandler) GetSeriesByID(c *gin.Context) {
+	var series models.Series
+	err := h.requestDB(c).
+		Preload("Volumes", func(db *gorm.DB) *gorm.DB { return db.Order("nomor") }).
+		Preload("Volumes.Komik").
+		Preload("Volumes.Chapters", func(db *gorm.DB) *gorm.DB { return db.Order("nomor") }).
+		First(&series, c.Param("id")).Error
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series tidak ditemukan"})
+		return
+	}
+
+	for i := range series.Volumes {
+		setVolumeAvailability(&series.Volumes[i])
+	}
+	c.JSON(http.StatusOK, series)
+}
+
+// CreateSeries godoc
+// @Summary Menambahkan series baru
+// @Tags Series
+// @Accept application/json
+// @Produce application/json
+// @Param data body models.Series true "Data Series"
+// @Success 201 {object} models.Series
+// @Router /series [post]
+// @Security BearerAuth
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var series models.Series
+	if err := c.ShouldBindJSON(&series); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	series.ID = 0
+	series.Volumes = nil
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&series).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, "series", series.ID, nil, series)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, series)
+}
+
+// UpdateSeries godoc
+// @Summary Memperbarui data series
+// @Tags Series
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Series"
+// @Param data body models.Series true "Data Series"
+// @Success 200 {object} models.Series
+// @Router /series/{id} [put]
+// @Security BearerAuth
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	var series models.Series
+	if err := h.requestDB(c).First(&series, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series tidak ditemukan"})
+		return
+	}
+
+	var input models.Series
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	before := series
+	series.Nama = input.Nama
+	series.Deskripsi = input.Deskripsi
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&series).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditUpdate, "series", series.ID, before, series)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, series)
+}
+
+// DeleteSeries godoc
+// @Summary Menghapus series
+// @Description Menghapus series beserta volume dan chapter-nya. Komik yang terhubung tidak ikut dihapus
+// @Tags Series
+// @Param id path int true "ID Series"
+// @Success 200 {string} string "Series berhasil dihapus"
+// @Router /series/{id} [delete]
+// @Security BearerAuth
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	var series models.Series
+	if err := h.requestDB(c).First(&series, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series tidak ditemukan"})
+		return
+	}
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := repository.DeleteVolumes(tx, "series_id = ?", series.ID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&series).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditDelete, "series", series.ID, series, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Series berhasil dihapus"})
+}
+
+// GetVolume godoc
+// @Summary Menampilkan volume tertentu dari series
+// @Description Menampilkan komik dan ketersediaan stok untuk nomor volume tertentu, misalnya untuk membeli volume 5
+// @Tags Series
+// @Produce application/json
+// @Param id path int true "ID Series"
+// @Param nomor path int true "Nomor Volume"
+// @Success 200 {object} models.Volume
+// @Router /series/{id}/volumes/{nomor} [get]
+// @Security BearerAuth
+func (h *SeriesHandler) GetVolume(c *gin.Context) {
+	var volume models.Volume
+	err := h.requestDB(c).Preload("Komik").
+		Preload("Chapters", func(db *gorm.DB) *gorm.DB { return db.Order("nomor") }).
+		Where("series_id = ? AND nomor = ?", c.Param("id"), c.Param("nomor")).
+		First(&volume).Error
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Volume tidak ditemukan"})
+		return
+	}
+
+	setVolumeAvailability(&volume)
+	c.JSON(http.StatusOK, volume)
+}
+
+// CreateVolume godoc
+// @Summary Menambahkan volume pada series
+// @Description Menghubungkan komik dengan series sebagai volume dengan nomor tertentu. Setiap komik hanya dapat menjadi satu volume
+// @Tags Series
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Series"
+// @Param data body models.Volume true "Data Volume (nomor, komik_id, judul)"
+// @Success 201 {object} models.Volume
+// @Failure 409 {object} map[string]string "Nomor volume atau komik sudah dipakai"
+// @Router /series/{id}/volumes [post]
+// @Security BearerAuth
+func (h *SeriesHandler) CreateVolume(c *gin.Context) {
+	var series models.Series
+	if err := h.requestDB(c).First(&series, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series tidak ditemukan"})
+		return
+	}
+
+	var volume models.Volume
+	if err := c.ShouldBindJSON(&volume); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	volume.ID = 0
+	volume.SeriesID = series.ID
+	volume.Komik = nil
+	volume.Chapters = nil
+
+	var komik models.Komik
+	if err := h.requestDB(c).First(&komik, volume.KomikID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Komik tidak ditemukan"})
+		return
+	}
+
+	var count int64
+	err := h.requestDB(c).Model(&models.Volume{}).
+		Where("(series_id = ? AND nomor = ?) OR komik_id = ?", series.ID, volume.Nomor, volume.KomikID).
+		Count(&count).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Nomor volume sudah ada di series ini atau komik sudah menjadi volume lain"})
+		return
+	}
+
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&volume).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, "volume", volume.ID, nil, volume)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	volume.Komik = &komik
+	setVolumeAvailability(&volume)
+	c.JSON(http.StatusCreated, volume)
+}
+
+// DeleteVolume godoc
+// @Summary Menghapus volume dari series
+// @Description Menghapus volume beserta chapter-nya. Komik yang terhubung tidak ikut dihapus
+// @Tags Series
+// @Param id path int true "ID Series"
+// @Param nomor path int true "Nomor Volume"
+// @Success 200 {string} string "Volume berhasil dihapus"
+// @Router /series/{id}/volumes/{nomor} [delete]
+// @Security BearerAuth
+func (h *SeriesHandler) DeleteVolume(c *gin.Context) {
+	var volume models.Volume
+	if err := h.requestDB(c).Where("series_id = ? AND nomor = ?", c.Param("id"), c.Param("nomor")).First(&volume).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Volume tidak ditemukan"})
+		return
+	}
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := repository.DeleteVolumes(tx, "id = ?", volume.ID); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditDelete, "volume", volume.ID, volume, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Volume berhasil dihapus"})
+}
+
+// CreateChapter godoc
+// @Summary Menambahkan chapter pada volume
+// @Tags Series
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Series"
+// @Param nomor path int true "Nomor Volume"
+// @Param data body models.Chapter true "Data Chapter (nomor, judul)"
+// @Success 201 {object} models.Chapter
+// @Failure 409 {object} map[string]string "Nomor chapter sudah ada"
+// @Router /series/{id}/volumes/{nomor}/chapters [post]
+// @Security BearerAuth
+func (h *SeriesHandler) CreateChapter(c *gin.Context) {
+	var volume models.Volume
+	if err := h.requestDB(c).Where("series_id = ? AND nomor = ?", c.Param("id"), c.Param("nomor")).First(&volume).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Volume tidak ditemukan"})
+		return
+	}
+
+	var chapter models.Chapter
+	if err := c.ShouldBindJSON(&chapter); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	chapter.ID = 0
+	chapter.VolumeID = volume.ID
+
+	var count int64
+	if err := h.requestDB(c).Model(&models.Chapter{}).Where("volume_id = ? AND nomor = ?", volume.ID, chapter.Nomor).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Nomor chapter sudah ada di volume ini"})
+		return
+	}
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&chapter).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, "chapter", chapter.ID, nil, chapter)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, chapter)
+}
+
+// DeleteChapter godoc
+// @Summary Menghapus chapter dari volume
+// @Tags Series
+// @Param id path int true "ID Series"
+// @Param nomor path int true "Nomor Volume"
+// @Param chapter path int true "Nomor Chapter"
+// @Success 200 {string} string "Chapter berhasil dihapus"
+// @Router /series/{id}/volumes/{nomor}/chapters/{chapter} [delete]
+// @Security BearerAuth
+func (h *SeriesHandler) DeleteChapter(c *gin.Context) {
+	var chapter models.Chapter
+	err := h.requestDB(c).Joins("JOIN volumes ON volumes.id = chapters.volume_id").
+		Where("volumes.series_id = ? AND volumes.nomor = ? AND chapters.nomor = ?", c.Param("id"), c.Param("nomor"), c.Param("chapter")).
+		First(&chapter).Error
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chapter tidak ditemukan"})
+		return
+	}
+
+	err = h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&chapter).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditDelete, "chapter", chapter.ID, chapter, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Chapter berhasil dihapus"})
+}
+
+// setVolumeAvailability mengisi status ketersediaan volume dari stok komik
+func setVolumeAvailability(volume *models.Volume) {
+	tersedia := volume.Komik != nil && volume.Komik.Stok > 0
+	volume.Tersedia = &tersedia
+}
@@ -0,0 +1,18 @@
+package controllers
+
+import (
+	"backend/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondBindError mengirim response 400. Error validasi diterjemahkan menjadi pesan per field
+// sesuai bahasa pada header Accept-Language
+func respondBindError(c *gin.Context, err error) {
+	if messages, ok := validation.Messages(err, c.GetHeader("Accept-Language")); ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Data tidak valid", "fields": messages})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
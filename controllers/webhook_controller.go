@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"backend/models"
+	"backend/webhook"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler menangani request pengaturan webhook dan riwayat pengirimannya
+type WebhookHandler struct {
+	database
+}
+
+// NewWebhookHandler membuat WebhookHandler. readDB dipakai untuk query baca dari request GET
+func NewWebhookHandler(db, readDB *gorm.DB) *WebhookHandler {
+	return &WebhookHandler{database{db: db, readDB: readDB}}
+}
+
+// WebhookInput adalah data langganan webhook dari admin
+type WebhookInput struct {
+	URL    string   `json:"url" binding:"required,http_url,max=2048"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=komik.created komik.updated komik.deleted komik.stock_changed comment.created comment.updated comment.deleted order.reserved order.confirmed order.released order.expired"`
+	Secret string   `json:"secret" binding:"omitempty,min=16,max=255"` // Wajib saat membuat webhook, kosongkan saat mengubah untuk memakai secret lama
+	Aktif  *bool    `json:"aktif"`                                     // Default true
+}
+
+// GetWebhooks godoc
+// @Summary Menampilkan semua webhook
+// @Tags Webhook
+// @Produce application/json
+// @Success 200 {array} models.Webhook
+// @Router /admin/webhooks [get]
+// @Security BearerAuth
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks := []models.Webhook{}
+	if err := h.requestDB(c).Order("id").Find(&webhooks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhookByID godoc
+// @Summary Menampilkan detail webhook
+// @Tags Webhook
+// @Produce application/json
+// @Param id path int true "ID Webhook"
+// @Success 200 {object} models.Webhook
+// @Router /admin/webhooks/{id} [get]
+// @Security BearerAuth
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	var w models.Webhook
+	if err := h.requestDB(c).First(&w, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook tidak ditemukan"})
+		return
+	}
+	c.JSON(http.StatusOK, w)
+}
+
+// CreateWebhook godoc
+// @Summary Menambahkan webhook
+// @Description Mendaftarkan URL yang menerima event komik, komentar dan pesanan sebagai HTTP POST berisi JSON. Setiap pengiriman membawa header X-Komik-Timestamp (detik Unix saat dikirim) dan X-Komik-Signature berisi "sha256=" dan HMAC-SHA256 hex dari "<timestamp>.<body>" dengan secret webhook. Penerima sebaiknya menolak timestamp yang lebih lama dari 5 menit agar pengiriman tidak dapat diputar ulang. Secret tidak pernah ditampilkan kembali
+// @Tags Webhook
+// @Accept application/json
+// @Produce application/json
+// @Param data body WebhookInput true "URL, event dan secret (minimal 16 karakter)"
+// @Success 201 {object} models.Webhook
+// @Router /admin/webhooks [post]
+// @Security BearerAuth
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var input WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if input.Secret == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Data tidak valid", "fields": gin.H{"secret": "secret wajib diisi"}})
+		return
+	}
+
+	w := models.Webhook{Aktif: true}
+	input.apply(&w)
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&w).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditCreate, "webhook", w.ID, nil, w)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, w)
+}
+
+// UpdateWebhook godoc
+// @Summary Mengubah webhook
+// @Description Mengganti URL, event dan status aktif webhook. Secret hanya diganti jika diisi. Pengiriman yang masih menunggu untuk webhook yang dinonaktifkan akan ditandai gagal
+// @Tags Webhook
+// @Accept application/json
+// @Produce application/json
+// @Param id path int true "ID Webhook"
+// @Param data body WebhookInput true "URL, event dan secret"
+// @Success 200 {object} models.Webhook
+// @Router /admin/webhooks/{id} [put]
+// @Security BearerAuth
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var w models.Webhook
+	if err := h.requestDB(c).First(&w, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook tidak ditemukan"})
+		return
+	}
+
+	var input WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	// Secret tidak pernah dicatat di audit log
+	before := w
+	input.apply(&w)
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("*").Save(&w).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditUpdate, "webhook", w.ID, before, w)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, w)
+}
+
+// DeleteWebhook godoc
+// @Summary Menghapus webhook
+// @Description Menghapus webhook beserta log pengirimannya
+// @Tags Webhook
+// @Param id path int true "ID Webhook"
+// @Success 200 {string} string "Webhook berhasil dihapus"
+// @Router /admin/webhooks/{id} [delete]
+// @Security BearerAuth
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	var w models.Webhook
+	if err := h.requestDB(c).First(&w, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook tidak ditemukan"})
+		return
+	}
+
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", w.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&w).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditDelete, "webhook", w.ID, w, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook berhasil dihapus"})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Menampilkan log pengiriman webhook
+// @Description Menampilkan pengiriman webhook dari yang terbaru beserta jumlah percobaan, status HTTP dan potongan respons terakhir. Pengiriman yang gagal dicoba ulang dengan jeda 30 detik yang berlipat dua setiap percobaan
+// @Tags Webhook
+// @Produce application/json
+// @Param id path int true "ID Webhook"
+// @Param status query string false "Filter status (menunggu, terkirim, gagal)"
+// @Param event query string false "Filter event, misalnya komik.updated"
+// @Param limit query int false "Jumlah pengiriman (default 50, maksimal 500)"
+// @Param offset query int false "Jumlah pengiriman yang dilewati"
+// @Success 200 {array} models.WebhookDelivery
+// @Router /admin/webhooks/{id}/deliveries [get]
+// @Security BearerAuth
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	var w models.Webhook
+	if err := h.requestDB(c).First(&w, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook tidak ditemukan"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	query := h.requestDB(c).Where("webhook_id = ?", w.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if event := c.Query("event"); event != "" {
+		query = query.Where("event = ?", event)
+	}
+
+	deliveries := []models.WebhookDelivery{}
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhook godoc
+// @Summary Mengirim ulang pengiriman webhook
+// @Description Membuat pengiriman baru dengan payload yang sama untuk segera dikirim oleh antrean. Pengiriman asal tetap tersimpan di log
+// @Tags Webhook
+// @Produce application/json
+// @Param id path int true "ID Webhook"
+// @Param delivery_id path int true "ID Pengiriman"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 409 {object} map[string]string "Webhook tidak aktif"
+// @Router /admin/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+// @Security BearerAuth
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	var w models.Webhook
+	if err := h.requestDB(c).First(&w, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook tidak ditemukan"})
+		return
+	}
+	var delivery models.WebhookDelivery
+	if err := h.requestDB(c).Where("webhook_id = ?", w.ID).First(&delivery, c.Param("delivery_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pengiriman tidak ditemukan"})
+		return
+	}
+	if !w.Aktif {
+		c.JSON(http.StatusConflict, gin.H{"error": "Webhook tidak aktif"})
+		return
+	}
+
+	var redelivery *models.WebhookDelivery
+	err := h.requestDB(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if redelivery, err = webhook.Redeliver(tx, delivery); err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditRedeliver, "webhook_delivery", delivery.ID, nil,
+			gin.H{"webhook_id": w.ID, "event": delivery.Event, "redelivery_id": redelivery.ID})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, redelivery)
+}
+
+// apply menyalin input ke webhook. Secret dan status aktif hanya diganti jika diisi
+func (input WebhookInput) apply(w *models.Webhook) {
+	w.URL = input.URL
+	w.Events = input.Events
+	if input.Secret != "" {
+		w.Secret = input.Secret
+	}
+	if input.Aktif != nil {
+		w.Aktif = *input.Aktif
+	}
+}
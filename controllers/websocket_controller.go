@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"backend/inventory"
+	"backend/models"
+	"backend/notification"
+	"backend/outbox"
+	"backend/websocket"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Struktur untuk pesan WebSocket
+type KomikUpdate struct {
+	KomikID uint   `json:"komik_id"`
+	Action  string `json:"action"` // "tambah" atau "kurang"
+}
+
+// WebSocketHandler menangani koneksi WebSocket untuk update stok komik
+type WebSocketHandler struct {
+	db  *gorm.DB
+	hub *websocket.Hub
+}
+
+// NewWebSocketHandler membuat WebSocketHandler yang mendaftarkan koneksi ke hub. Perubahan
+// stok dari klien disimpan di db
+func NewWebSocketHandler(db *gorm.DB, hub *websocket.Hub) *WebSocketHandler {
+	return &WebSocketHandler{db: db, hub: hub}
+}
+
+// HandleWebSocket godoc
+// @Summary Mengelola koneksi WebSocket (versi lama)
+// @Description Menyediakan koneksi WebSocket untuk memperbarui stok komik secara real-time. Klien dengan token mengirim {"komik_id", "action": "tambah"|"kurang"} dan server mengirim data komik dengan stok terbaru setiap kali stok berubah. Perubahan stok dari koneksi tanpa token ditutup dengan close code 1008. Event lain hanya tersedia di /v2/komik/updates
+// @Tags WebSocket
+// @Produce application/json
+// @Param token query string false "Token JWT (tanpa Bearer)"
+// @Success 101 {object} models.Komik
+// @Router /komik/updates [get]
+func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
+	h.hub.Upgrade(c, websocket.FormatLegacy, h.handleStockMessage)
+}
+
+// HandleEvents godoc
+// @Summary Mengelola koneksi WebSocket untuk event
+// @Description Menyediakan koneksi WebSocket untuk memperbarui stok komik secara real-time. Klien dengan token mengirim {"komik_id", "action": "tambah"|"kurang"} dan server mengirim event {"type", "data"}: "stock_updated" berisi komik dengan stok terbaru dan "stock_low" jika stok komik di bawah reorder level. Perubahan stok dari koneksi tanpa token dibalas event {"type": "error", "data": "autentikasi diperlukan"}. Jika token dikirim lewat query token atau header Authorization, server juga mengirim event "notification" untuk user tersebut
+// @Tags WebSocket
+// @Produce application/json
+// @Param token query string false "Token JWT (tanpa Bearer)"
+// @Success 101 {object} websocket.Event
+// @Router /v2/komik/updates [get]
+func (h *WebSocketHandler) HandleEvents(c *gin.Context) {
+	h.hub.Upgrade(c, websocket.FormatEvent, h.handleStockMessage)
+}
+
+// errPerluToken dikembalikan untuk perubahan stok dari koneksi tanpa token
+var errPerluToken = errors.New("autentikasi diperlukan")
+
+// handleStockMessage memproses update stok komik dari klien. User diambil dari token, sehingga
+// pesan dari koneksi tanpa token ditolak dengan errPerluToken. Pesan yang tidak valid dan
+// perubahan yang gagal hanya dicatat di log
+func (h *WebSocketHandler) handleStockMessage(ctx context.Context, userID uint, message []byte) error {
+	var update KomikUpdate
+	if err := json.Unmarshal(message, &update); err != nil {
+		slog.WarnContext(ctx, "Pesan WebSocket tidak valid", "user_id", userID, "error", err)
+		return nil
+	}
+	if userID == 0 {
+		slog.WarnContext(ctx, "Perubahan stok lewat WebSocket memerlukan token", "komik_id", update.KomikID, "aksi", update.Action)
+		return errPerluToken
+	}
+
+	// "kurang" berarti komik dibeli, "tambah" berarti pembelian dibatalkan dan komik dikembalikan
+	movement := models.StockMovement{KomikID: update.KomikID, UserID: &userID, Referensi: "websocket"}
+	switch update.Action {
+	case "tambah":
+		movement.Delta, movement.Alasan = 1, models.AlasanReturn
+	case "kurang":
+		movement.Delta, movement.Alasan = -1, models.AlasanSale
+	default:
+		slog.WarnContext(ctx, "Aksi stok tidak dikenal", "user_id", userID, "aksi", update.Action)
+		return nil
+	}
+	err := h.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Stok yang sedang ditahan reservasi user lain tidak dapat dibeli
+		if movement.Delta < 0 {
+			if err := inventory.CheckAvailable(tx, update.KomikID, userID, -movement.Delta, time.Now()); err != nil {
+				return err
+			}
+		}
+		if err := inventory.Record(tx, &movement); err != nil {
+			return err
+		}
+		if err := notifyBackInStock(tx, &movement); err != nil {
+			return err
+		}
+
+		// Data stok terbaru dikirim ke semua klien setelah perubahan tersimpan
+		var komik models.Komik
+		if err := tx.First(&komik, update.KomikID).Error; err != nil {
+			return err
+		}
+		return outbox.Broadcast(tx, websocket.EventStockUpdated, komik)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal memperbarui stok komik", "user_id", userID, "komik_id", update.KomikID, "error", err)
+	}
+	return nil
+}
+
+// notifyBackInStock memberi tahu user yang menyimpan komik di wishlist jika perubahan stok
+// membuat komik tersebut tersedia kembali. Dipanggil di dalam transaksi perubahan stok
+func notifyBackInStock(tx *gorm.DB, movement *models.StockMovement) error {
+	if !inventory.BackInStock(movement) {
+		return nil
+	}
+	return notification.NotifyBackInStock(tx, movement.KomikID)
+}
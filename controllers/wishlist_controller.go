@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"backend/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WishlistHandler menangani request wishlist user
+type WishlistHandler struct {
+	database
+}
+
+// NewWishlistHandler membuat WishlistHandler. readDB dipakai untuk query baca dari request GET
+func NewWishlistHandler(db, readDB *gorm.DB) *WishlistHandler {
+	return &WishlistHandler{database{db: db, readDB: readDB}}
+}
+
+// WishlistInput adalah komik yang ditambahkan ke wishlist
+type WishlistInput struct {
+	KomikID uint `json:"komik_id" binding:"required"`
+}
+
+// GetWishlist godoc
+// @Summary Menampilkan wishlist
+// @Description Menampilkan komik di wishlist user yang login dari yang terakhir ditambahkan
+// @Tags Wishlist
+// @Produce application/json
+// @Success 200 {array} models.Wishlist
+// @Router /wishlist [get]
+// @Security BearerAuth
+func (h *WishlistHandler) GetWishlist(c *gin.Context) {
+	wishlist := []models.Wishlist{}
+	err := h.requestDB(c).Preload("Komik").
+		Where("user_id = ?", c.MustGet("user_id")).
+		Order("created_at DESC, id DESC").
+		Find(&wishlist).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// AddWishlist godoc
+// @Summary Menambahkan komik ke wishlist
+// @Description Menyimpan komik di wishlist user yang login. Saat stok komik yang habis tersedia kembali, user diberi notifikasi. Komik yang sudah ada di wishlist tidak ditambahkan lagi
+// @Tags Wishlist
+// @Accept application/json
+// @Produce application/json
+// @Param data body WishlistInput true "Komik yang ditambahkan"
+// @Success 201 {object} models.Wishlist
+// @Success 200 {object} models.Wishlist "Komik sudah ada di wishlist"
+// @Failure 404 {object} map[string]string "Komik tidak ditemukan"
+// @Router /wishlist [post]
+// @Security BearerAuth
+func (h *WishlistHandler) AddWishlist(c *gin.Context) {
+	var input WishlistInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var komik models.Komik
+	if err := h.requestDB(c).First(&komik, input.KomikID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Komik tidak ditemukan"})
+		return
+	}
+
+	item := models.Wishlist{UserID: c.MustGet("user_id").(uint), KomikID: komik.ID}
+	result := h.requestDB(c).Where(item).FirstOrCreate(&item)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	item.Komik = &komik
+
+	status := http.StatusCreated
+	if result.RowsAffected == 0 {
+		status = http.StatusOK
+	}
+	c.JSON(status, item)
+}
+
+// RemoveWishlist godoc
+// @Summary Menghapus komik dari wishlist
+// @Tags Wishlist
+// @Param komik_id path int true "ID Komik"
+// @Success 200 {string} string "Komik dihapus dari wishlist"
+// @Router /wishlist/{komik_id} [delete]
+// @Security BearerAuth
+func (h *WishlistHandler) RemoveWishlist(c *gin.Context) {
+	result := h.requestDB(c).Where("user_id = ? AND komik_id = ?", c.MustGet("user_id"), c.Param("komik_id")).Delete(&models.Wishlist{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Komik tidak ada di wishlist"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Komik dihapus dari wishlist"})
+}
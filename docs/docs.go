@@ -22,231 +22,361 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/comments": {
+        "/admin/audit-logs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat melihat semua komentar, sedangkan user hanya dapat melihat komentarnya sendiri",
+                "description": "Menampilkan aksi admin dari yang terbaru beserta pelaku, perubahan data (nilai sebelum dan sesudah setiap field yang berubah), IP dan ID request. Audit log tidak dapat diubah atau dihapus",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Komentar"
+                    "Admin"
+                ],
+                "summary": "Menampilkan audit log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter ID admin pelaku",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter aksi (create, update, delete, import, reconcile, redeliver)",
+                        "name": "aksi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter entitas, misalnya komik atau comment",
+                        "name": "entitas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter ID data",
+                        "name": "entitas_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter ID request",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu awal (RFC3339 atau YYYY-MM-DD)",
+                        "name": "dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir (RFC3339 atau YYYY-MM-DD, tanggal akhir ikut disertakan)",
+                        "name": "sampai",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah audit log (default 50, maksimal 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah audit log yang dilewati",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Menampilkan semua komentar",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Comment"
+                                "$ref": "#/definitions/models.AuditLog"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Filter tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/audit-logs/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "User dapat membuat komentar baru",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengunduh audit log yang sesuai filter sebagai CSV atau JSON, diurutkan dari yang terlama. Data dikirim bertahap sehingga log besar tidak perlu dimuat sekaligus",
                 "produces": [
+                    "text/csv",
                     "application/json"
                 ],
                 "tags": [
-                    "Komentar"
+                    "Admin"
                 ],
-                "summary": "Membuat komentar baru",
+                "summary": "Mengekspor audit log",
                 "parameters": [
                     {
-                        "description": "Data Komentar",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Comment"
-                        }
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter ID admin pelaku",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter aksi",
+                        "name": "aksi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter entitas",
+                        "name": "entitas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter ID data",
+                        "name": "entitas_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter ID request",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu awal (RFC3339 atau YYYY-MM-DD)",
+                        "name": "dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir (RFC3339 atau YYYY-MM-DD, tanggal akhir ikut disertakan)",
+                        "name": "sampai",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "File ekspor",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Filter atau format tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/comments/{id}": {
-            "put": {
+        "/admin/inventory/reconcile": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "User dapat memperbarui komentarnya sendiri",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mencari komik yang stoknya berbeda dengan jumlah catatan perubahan stok, lalu menambahkan catatan adjustment sebesar selisihnya. Stok komik tidak diubah",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Komentar"
-                ],
-                "summary": "Memperbarui komentar",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID Komentar",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data Komentar yang Diperbarui",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Comment"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "Merekonsiliasi catatan stok",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/inventory.Rekonsiliasi"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/komik/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat menghapus komentar siapa saja, sedangkan user hanya dapat menghapus komentarnya sendiri",
+                "description": "Mengunduh seluruh katalog komik sebagai CSV, JSON atau Excel. Data dikirim bertahap sehingga katalog besar tidak perlu dimuat sekaligus",
+                "produces": [
+                    "text/csv",
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
                 "tags": [
-                    "Komentar"
+                    "Admin"
                 ],
-                "summary": "Menghapus komentar",
+                "summary": "Mengekspor katalog komik",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID Komentar",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "csv",
+                            "json",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Komentar berhasil dihapus",
+                        "description": "File ekspor",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/komik": {
-            "get": {
-                "description": "Mengambil semua data komik dari database",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Komik"
-                ],
-                "summary": "Menampilkan semua data komik",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Komik"
-                            }
-                        }
-                    }
-                }
-            },
+        "/admin/komik/import": {
             "post": {
-                "description": "Membuat data komik baru di database",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengimpor komik dari CSV (baris pertama berisi nama kolom) atau NDJSON (satu objek JSON per baris). File dapat dikirim sebagai body dengan Content-Type text/csv atau application/x-ndjson, atau sebagai field 'file' pada multipart/form-data. Kolom: nama, isbn10, isbn13, author, genre, tahun_terbit, publisher, stok, reorder_level, harga, mata_uang. Komik dengan ISBN atau nama yang sama diperbarui, selain itu dibuat baru. Semua baris disimpan dalam satu transaksi: jika ada baris yang tidak valid tidak ada data yang disimpan",
                 "consumes": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Komik"
+                    "Admin"
                 ],
-                "summary": "Menambahkan komik baru",
+                "summary": "Mengimpor katalog komik",
                 "parameters": [
                     {
-                        "description": "Data Komik",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Komik"
-                        }
+                        "type": "boolean",
+                        "description": "Hanya memeriksa data tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Format file jika tidak dapat ditentukan dari Content-Type atau nama file",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File impor",
+                        "name": "file",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Komik"
+                            "$ref": "#/definitions/bulk.Report"
+                        }
+                    },
+                    "422": {
+                        "description": "Ada baris yang tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/bulk.Report"
                         }
                     }
                 }
             }
         },
-        "/komik/updates": {
+        "/admin/komik/low-stock": {
             "get": {
-                "description": "Menyediakan koneksi WebSocket untuk memperbarui stok komik secara real-time",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan komik yang stoknya di bawah reorder level, dimulai dari yang paling jauh di bawah reorder level",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "WebSocket"
+                    "Admin"
                 ],
-                "summary": "Mengelola koneksi WebSocket",
+                "summary": "Menampilkan komik dengan stok rendah",
                 "responses": {
                     "200": {
-                        "description": "Client terhubung",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Komik"
+                            }
                         }
                     }
                 }
             }
         },
-        "/komik/{id}": {
+        "/admin/komik/{id}/stock": {
             "get": {
-                "description": "Mengambil data komik dari database menggunakan ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan catatan perubahan stok komik dari yang terbaru, beserta perbandingan stok dengan jumlah seluruh catatan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Komik"
+                    "Admin"
                 ],
-                "summary": "Menampilkan detail komik berdasarkan ID",
+                "summary": "Menampilkan riwayat stok komik",
                 "parameters": [
                     {
                         "type": "integer",
@@ -254,19 +384,36 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah catatan (default 50, maksimal 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah catatan yang dilewati",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Komik"
+                            "$ref": "#/definitions/controllers.RiwayatStok"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Memperbarui data komik berdasarkan ID",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambah atau mengurangi stok komik sekaligus mencatatnya, misalnya saat barang datang dari supplier (restock) atau dikembalikan pembeli (return)",
                 "consumes": [
                     "application/json"
                 ],
@@ -274,9 +421,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Komik"
+                    "Admin"
                 ],
-                "summary": "Memperbarui data komik",
+                "summary": "Mencatat perubahan stok komik",
                 "parameters": [
                     {
                         "type": "integer",
@@ -286,95 +433,4083 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data Komik yang Diperbarui",
+                        "description": "Perubahan stok",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Komik"
+                            "$ref": "#/definitions/controllers.StockMovementInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Komik"
+                            "$ref": "#/definitions/models.StockMovement"
+                        }
+                    },
+                    "409": {
+                        "description": "Stok tidak mencukupi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Menghapus data komik berdasarkan ID",
-                "tags": [
-                    "Komik"
-                ],
-                "summary": "Menghapus data komik",
-                "parameters": [
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "ID Komik",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Menampilkan semua webhook",
                 "responses": {
                     "200": {
-                        "description": "Data berhasil dihapus",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.Comment": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
-                },
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendaftarkan URL yang menerima event komik, komentar dan pesanan sebagai HTTP POST berisi JSON. Setiap pengiriman membawa header X-Komik-Timestamp (detik Unix saat dikirim) dan X-Komik-Signature berisi \"sha256=\" dan HMAC-SHA256 hex dari \"\u003ctimestamp\u003e.\u003cbody\u003e\" dengan secret webhook. Penerima sebaiknya menolak timestamp yang lebih lama dari 5 menit agar pengiriman tidak dapat diputar ulang. Secret tidak pernah ditampilkan kembali",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Menambahkan webhook",
+                "parameters": [
+                    {
+                        "description": "URL, event dan secret (minimal 16 karakter)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Menampilkan detail webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti URL, event dan status aktif webhook. Secret hanya diganti jika diisi. Pengiriman yang masih menunggu untuk webhook yang dinonaktifkan akan ditandai gagal",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Mengubah webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "URL, event dan secret",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus webhook beserta log pengirimannya",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Menghapus webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan pengiriman webhook dari yang terbaru beserta jumlah percobaan, status HTTP dan potongan respons terakhir. Pengiriman yang gagal dicoba ulang dengan jeda 30 detik yang berlipat dua setiap percobaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Menampilkan log pengiriman webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status (menunggu, terkirim, gagal)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter event, misalnya komik.updated",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah pengiriman (default 50, maksimal 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah pengiriman yang dilewati",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat pengiriman baru dengan payload yang sama untuk segera dikirim oleh antrean. Pengiriman asal tetap tersimpan di log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Mengirim ulang pengiriman webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID Pengiriman",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "409": {
+                        "description": "Webhook tidak aktif",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/authors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menampilkan semua author",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Author"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama yang sama setelah dinormalisasi (huruf besar/kecil, urutan kata, tanda baca) dianggap duplikat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menambahkan author baru",
+                "parameters": [
+                    {
+                        "description": "Data Author",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KatalogInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Author"
+                        }
+                    },
+                    "409": {
+                        "description": "Author sudah ada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/authors/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menampilkan detail author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Author",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Author"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama author pada komik yang memakai author ini ikut diperbarui",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Mengubah nama author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Author",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Author",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KatalogInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Author"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Author yang masih dipakai oleh komik tidak dapat dihapus",
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menghapus author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Author",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Author berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Author masih dipakai oleh komik",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat melihat semua komentar, sedangkan user hanya dapat melihat komentarnya sendiri",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Menampilkan semua komentar",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comment"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "User dapat membuat komentar baru. Untuk membalas, sebut user lain dengan @username; user yang disebut dan sudah berkomentar pada komik yang sama mendapat notifikasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Membuat komentar baru",
+                "parameters": [
+                    {
+                        "description": "Data Komentar",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil satu komentar beserta jumlah reaksinya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Menampilkan detail komentar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komentar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data komentar"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "User dapat memperbarui komentarnya sendiri",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Memperbarui komentar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komentar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari komentar yang akan diubah",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Komentar yang Diperbarui",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CommentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    "412": {
+                        "description": "Komentar sudah diubah oleh request lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat menghapus komentar siapa saja, sedangkan user hanya dapat menghapus komentarnya sendiri. Jika admin menghapus komentar user, pemiliknya mendapat notifikasi beserta alasannya. Riwayat edit komentar yang dihapus tetap disimpan dan dapat dilihat admin.",
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Menghapus komentar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komentar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alasan penghapusan oleh admin, dikirim ke pemilik komentar",
+                        "name": "alasan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari komentar yang akan dihapus",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Komentar berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Komentar sudah diubah oleh request lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "User dapat memperbarui komentarnya sendiri menggunakan JSON Merge Patch (RFC 7396)",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Memperbarui sebagian komentar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komentar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari komentar yang akan diubah",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Field komentar yang diubah (komentar)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    "412": {
+                        "description": "Komentar sudah diubah oleh request lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Content-Type tidak didukung",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan isi komentar sebelum diedit, diurutkan dari yang paling lama. Hanya dapat diakses oleh pemilik komentar dan admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Menampilkan riwayat edit komentar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komentar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CommentRevision"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{id}/reactions/{reaksi}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jika user belum memberi reaksi tersebut maka reaksi ditambahkan, jika sudah maka reaksi dibatalkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Memberi atau membatalkan reaksi pada komentar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komentar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "haha",
+                            "wow",
+                            "sad",
+                            "angry"
+                        ],
+                        "type": "string",
+                        "description": "Jenis reaksi",
+                        "name": "reaksi",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    }
+                }
+            }
+        },
+        "/discounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harga"
+                ],
+                "summary": "Menampilkan semua diskon",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Hanya diskon yang sedang berlaku",
+                        "name": "aktif",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Diskon"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Diskon berjenis persen (nilai 1-100) atau nominal (potongan dalam satuan terkecil mata uang) untuk tepat satu dari komik_id, genre_id atau publisher_id, berlaku dari mulai sampai sebelum berakhir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harga"
+                ],
+                "summary": "Menambahkan diskon baru",
+                "parameters": [
+                    {
+                        "description": "Data Diskon",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Diskon"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Diskon"
+                        }
+                    }
+                }
+            }
+        },
+        "/discounts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harga"
+                ],
+                "summary": "Menampilkan detail diskon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Diskon",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Diskon"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harga"
+                ],
+                "summary": "Mengganti data diskon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Diskon",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Diskon",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Diskon"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Diskon"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Harga"
+                ],
+                "summary": "Menghapus diskon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Diskon",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Diskon berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menampilkan semua genre",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama yang sama setelah dinormalisasi (huruf besar/kecil, urutan kata, tanda baca) dianggap duplikat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menambahkan genre baru",
+                "parameters": [
+                    {
+                        "description": "Data Genre",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KatalogInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "409": {
+                        "description": "Genre sudah ada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menampilkan detail genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Genre",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Teks genre pada komik yang memakai genre ini ikut diperbarui",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Mengubah nama genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Genre",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Genre",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KatalogInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Genre yang masih dipakai oleh komik tidak dapat dihapus",
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menghapus genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Genre",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Genre berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Genre masih dipakai oleh komik",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Selalu 200 selama proses server berjalan dan dapat melayani request. Tidak memeriksa database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/isbn/{isbn}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil judul, author, publisher, genre dan tahun terbit dari provider metadata (Open Library) untuk mengisi form komik. Nama field sama dengan field komik",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Mencari data buku berdasarkan ISBN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-10 atau ISBN-13, boleh dengan tanda hubung",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ISBNLookupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ISBN tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Data buku tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Provider metadata tidak dapat dihubungi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/komik": {
+            "get": {
+                "description": "Mengambil semua data komik dari database, dapat difilter berdasarkan author, publisher dan genre",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Menampilkan semua data komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter ID Author",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter ID Publisher",
+                        "name": "publisher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter ID Genre",
+                        "name": "genre_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Komik"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Membuat data komik baru di database. Author, publisher dan genre dapat dipilih dengan author_id, publisher_id dan genre_ids, atau dikirim sebagai teks dan akan dicari atau dibuat di katalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Menambahkan komik baru",
+                "parameters": [
+                    {
+                        "description": "Data Komik",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        }
+                    },
+                    "409": {
+                        "description": "ISBN sudah dipakai oleh komik lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/komik/updates": {
+            "get": {
+                "description": "Menyediakan koneksi WebSocket untuk memperbarui stok komik secara real-time. Klien dengan token mengirim {\"komik_id\", \"action\": \"tambah\"|\"kurang\"} dan server mengirim data komik dengan stok terbaru setiap kali stok berubah. Perubahan stok dari koneksi tanpa token ditutup dengan close code 1008. Event lain hanya tersedia di /v2/komik/updates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebSocket"
+                ],
+                "summary": "Mengelola koneksi WebSocket (versi lama)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token JWT (tanpa Bearer)",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        }
+                    }
+                }
+            }
+        },
+        "/komik/{id}": {
+            "get": {
+                "description": "Mengambil data komik dari database menggunakan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Menampilkan detail komik berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data komik"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Mengganti seluruh data komik berdasarkan ID. Semua field wajib dikirim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Mengganti data komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari data komik yang akan diubah",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Komik yang Diperbarui",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KomikInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        }
+                    },
+                    "409": {
+                        "description": "ISBN sudah dipakai oleh komik lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah oleh pengguna lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus data komik berdasarkan ID",
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Menghapus data komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari data komik yang akan dihapus",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah oleh pengguna lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Memperbarui data komik menggunakan JSON Merge Patch (RFC 7396). Field yang tidak dikirim tidak berubah, field bernilai null dikosongkan",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Memperbarui sebagian data komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari data komik yang akan diubah",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Field komik yang diubah (nama, isbn10, isbn13, author, genre, tahun_terbit, publisher, stok, reorder_level, harga, mata_uang, author_id, publisher_id, genre_ids)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        }
+                    },
+                    "409": {
+                        "description": "ISBN sudah dipakai oleh komik lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah oleh pengguna lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Content-Type tidak didukung",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/komik/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua komentar untuk komik tertentu beserta jumlah reaksinya. Gunakan sort=top untuk mengurutkan berdasarkan jumlah reaksi terbanyak",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komentar"
+                ],
+                "summary": "Menampilkan komentar pada sebuah komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "terbaru",
+                            "top"
+                        ],
+                        "type": "string",
+                        "description": "Urutan komentar",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comment"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/komik/{id}/cover": {
+            "get": {
+                "description": "Menampilkan gambar cover asli atau thumbnail-nya. Dapat diakses tanpa token agar bisa dipakai langsung di tag img. URL dengan parameter v (dari cover_url) di-cache selamanya oleh browser",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Menampilkan cover komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kecil",
+                            "sedang",
+                            "besar"
+                        ],
+                        "type": "string",
+                        "description": "Ukuran thumbnail",
+                        "name": "ukuran",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versi cover dari cover_url",
+                        "name": "v",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gambar cover",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Cover tidak berubah",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunggah gambar cover (JPEG, PNG, GIF atau WebP, maksimal 5 MB). Jenis file ditentukan dari isinya, bukan dari nama file. Thumbnail ukuran kecil, sedang dan besar dibuat otomatis dan cover lama dihapus",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Mengunggah cover komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari komik yang akan diubah",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File gambar cover",
+                        "name": "cover",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Komik"
+                        }
+                    },
+                    "412": {
+                        "description": "Komik sudah diubah oleh request lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "File terlalu besar",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Jenis file tidak didukung",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus cover beserta semua thumbnail-nya",
+                "tags": [
+                    "Komik"
+                ],
+                "summary": "Menghapus cover komik",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari komik yang akan diubah",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cover berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Komik sudah diubah oleh request lain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match tidak dikirim",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan notifikasi user yang login dari yang terbaru: komik di wishlist yang tersedia kembali (back_in_stock), balasan komentar (comment_reply), perubahan status pesanan (order_status) dan hasil moderasi komentar (moderation)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifikasi"
+                ],
+                "summary": "Menampilkan kotak masuk notifikasi",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Hanya notifikasi yang belum dibaca",
+                        "name": "belum_dibaca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah notifikasi (default 50, maksimal 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah notifikasi yang dilewati",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifikasi"
+                ],
+                "summary": "Menandai semua notifikasi sudah dibaca",
+                "responses": {
+                    "200": {
+                        "description": "Jumlah notifikasi yang ditandai",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Waktu dibaca tidak berubah jika notifikasi sudah pernah ditandai",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifikasi"
+                ],
+                "summary": "Menandai notifikasi sudah dibaca",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Notifikasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Notification"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/quote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghitung harga setiap komik beserta diskon yang berlaku saat ini. Satu baris hanya memakai satu diskon dengan potongan terbesar. Semua harga dalam satuan terkecil mata uang dan total dihitung per mata uang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harga"
+                ],
+                "summary": "Menghitung harga komik",
+                "parameters": [
+                    {
+                        "description": "Komik dan jumlah yang dibeli",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.QuoteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pricing.Penawaran"
+                        }
+                    },
+                    "400": {
+                        "description": "Jumlah satu komik lebih dari 10000 atau total harga terlalu besar",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Komik tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menampilkan semua publisher",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Publisher"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama yang sama setelah dinormalisasi (huruf besar/kecil, urutan kata, tanda baca) dianggap duplikat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menambahkan publisher baru",
+                "parameters": [
+                    {
+                        "description": "Data Publisher",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KatalogInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publisher"
+                        }
+                    },
+                    "409": {
+                        "description": "Publisher sudah ada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menampilkan detail publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Publisher",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publisher"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama publisher pada komik yang memakai publisher ini ikut diperbarui",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Mengubah nama publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Publisher",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Publisher",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.KatalogInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publisher"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publisher yang masih dipakai oleh komik tidak dapat dihapus",
+                "tags": [
+                    "Katalog"
+                ],
+                "summary": "Menghapus publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Publisher",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Publisher berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Publisher masih dipakai oleh komik",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "200 jika database utama dapat dihubungi dan migrasi sudah selesai. Replika database tidak diperiksa. 503 jika salah satunya gagal atau server sedang berhenti, sehingga load balancer tidak mengirim request baru",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan reservasi milik user yang login dari yang terbaru. Admin dapat melihat reservasi semua user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservasi"
+                ],
+                "summary": "Menampilkan reservasi stok",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status (aktif, dikonfirmasi, dilepas, kedaluwarsa)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Reservation"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menahan stok komik untuk user yang login selama waktu tertentu (default 15 menit). Stok yang ditahan tidak dapat dipesan atau dibeli user lain sampai reservasi dikonfirmasi, dilepas atau kedaluwarsa. Jika user sudah memiliki reservasi aktif untuk komik yang sama, jumlahnya diganti dan waktunya diperpanjang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservasi"
+                ],
+                "summary": "Menahan stok komik selama checkout",
+                "parameters": [
+                    {
+                        "description": "Komik dan jumlah yang ditahan",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReservationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "404": {
+                        "description": "Komik tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Stok yang tersedia tidak mencukupi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah reservasi aktif milik user menjadi penjualan: stok komik dikurangi dan dicatat sebagai sale dengan referensi pesanan. Semua reservasi dikonfirmasi dalam satu transaksi; jika salah satu gagal tidak ada yang dikonfirmasi. User mendapat notifikasi order_status untuk setiap reservasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservasi"
+                ],
+                "summary": "Mengonfirmasi pesanan dari reservasi stok",
+                "parameters": [
+                    {
+                        "description": "Referensi pesanan dan reservasi yang dikonfirmasi",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConfirmReservationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Reservation"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Reservasi tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Reservasi sudah tidak aktif atau stok tidak mencukupi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membatalkan reservasi aktif sehingga stoknya dapat dipesan user lain. Hanya pemilik reservasi atau admin; jika dibatalkan admin, pemiliknya mendapat notifikasi",
+                "tags": [
+                    "Reservasi"
+                ],
+                "summary": "Melepas reservasi stok",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Reservasi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservasi sudah tidak aktif",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menampilkan semua series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Series"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menambahkan series baru",
+                "parameters": [
+                    {
+                        "description": "Data Series",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan series dengan daftar volume yang diurutkan berdasarkan nomor, lengkap dengan komik, stok dan chapter setiap volume",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menampilkan detail series beserta volumenya",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Memperbarui data series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Series",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Series"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus series beserta volume dan chapter-nya. Komik yang terhubung tidak ikut dihapus",
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menghapus series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/volumes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghubungkan komik dengan series sebagai volume dengan nomor tertentu. Setiap komik hanya dapat menjadi satu volume",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menambahkan volume pada series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Volume (nomor, komik_id, judul)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Volume"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Volume"
+                        }
+                    },
+                    "409": {
+                        "description": "Nomor volume atau komik sudah dipakai",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/volumes/{nomor}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan komik dan ketersediaan stok untuk nomor volume tertentu, misalnya untuk membeli volume 5",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menampilkan volume tertentu dari series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor Volume",
+                        "name": "nomor",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Volume"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus volume beserta chapter-nya. Komik yang terhubung tidak ikut dihapus",
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menghapus volume dari series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor Volume",
+                        "name": "nomor",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Volume berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/volumes/{nomor}/chapters": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menambahkan chapter pada volume",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor Volume",
+                        "name": "nomor",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Chapter (nomor, judul)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Chapter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Chapter"
+                        }
+                    },
+                    "409": {
+                        "description": "Nomor chapter sudah ada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/volumes/{nomor}/chapters/{chapter}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Menghapus chapter dari volume",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Series",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor Volume",
+                        "name": "nomor",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor Chapter",
+                        "name": "chapter",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chapter berhasil dihapus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/komik/updates": {
+            "get": {
+                "description": "Menyediakan koneksi WebSocket untuk memperbarui stok komik secara real-time. Klien dengan token mengirim {\"komik_id\", \"action\": \"tambah\"|\"kurang\"} dan server mengirim event {\"type\", \"data\"}: \"stock_updated\" berisi komik dengan stok terbaru dan \"stock_low\" jika stok komik di bawah reorder level. Perubahan stok dari koneksi tanpa token dibalas event {\"type\": \"error\", \"data\": \"autentikasi diperlukan\"}. Jika token dikirim lewat query token atau header Authorization, server juga mengirim event \"notification\" untuk user tersebut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebSocket"
+                ],
+                "summary": "Mengelola koneksi WebSocket untuk event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token JWT (tanpa Bearer)",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/websocket.Event"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan komik di wishlist user yang login dari yang terakhir ditambahkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "Menampilkan wishlist",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Wishlist"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menyimpan komik di wishlist user yang login. Saat stok komik yang habis tersedia kembali, user diberi notifikasi. Komik yang sudah ada di wishlist tidak ditambahkan lagi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "Menambahkan komik ke wishlist",
+                "parameters": [
+                    {
+                        "description": "Komik yang ditambahkan",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WishlistInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Komik sudah ada di wishlist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Wishlist"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Komik tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist/{komik_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "Menghapus komik dari wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Komik",
+                        "name": "komik_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Komik dihapus dari wishlist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "bulk.HasilBaris": {
+            "type": "object",
+            "properties": {
+                "aksi": {
+                    "type": "string"
+                },
+                "baris": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "komik_id": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "bulk.Report": {
+            "type": "object",
+            "properties": {
+                "baris": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bulk.HasilBaris"
+                    }
+                },
+                "dibuat": {
+                    "type": "integer"
+                },
+                "diperbarui": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.CommentInput": {
+            "type": "object",
+            "required": [
+                "komentar"
+            ],
+            "properties": {
+                "komentar": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ConfirmReservationInput": {
+            "type": "object",
+            "properties": {
+                "referensi": {
+                    "description": "Nomor pesanan, default \"reservasi \u003cid\u003e\"",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "reservation_ids": {
+                    "description": "Kosongkan untuk mengonfirmasi semua reservasi aktif milik user",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "controllers.ISBNLookupResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "string"
+                },
+                "isbn10": {
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "komik_id": {
+                    "description": "Diisi jika komik dengan ISBN ini sudah ada",
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "tahun_terbit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.KatalogInput": {
+            "type": "object",
+            "properties": {
+                "nama": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "controllers.KomikInput": {
+            "type": "object",
+            "required": [
+                "harga",
+                "nama",
+                "stok",
+                "tahun_terbit"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "integer"
+                },
+                "genre": {
+                    "type": "string"
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "harga": {
+                    "type": "integer"
+                },
+                "isbn10": {
+                    "description": "Boleh tidak dikirim, dilengkapi dari isbn13 jika memungkinkan",
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "mata_uang": {
+                    "description": "Default IDR jika tidak dikirim",
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "publisher_id": {
+                    "type": "integer"
+                },
+                "reorder_level": {
+                    "description": "Default 0 (tanpa peringatan stok rendah) jika tidak dikirim",
+                    "type": "integer"
+                },
+                "stok": {
+                    "type": "integer"
+                },
+                "tahun_terbit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.QuoteInput": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/pricing.Item"
+                    }
+                }
+            }
+        },
+        "controllers.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "description": "\"ok\" atau pesan error ping database",
+                    "type": "string"
+                },
+                "migrasi": {
+                    "description": "\"selesai\" atau \"belum_selesai\"",
+                    "type": "string"
+                },
+                "server": {
+                    "description": "\"berhenti\" jika server sedang dimatikan",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"siap\" atau \"tidak_siap\"",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ReservationInput": {
+            "type": "object",
+            "required": [
+                "jumlah",
+                "komik_id"
+            ],
+            "properties": {
+                "jumlah": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "komik_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.RiwayatStok": {
+            "type": "object",
+            "properties": {
+                "komik_id": {
+                    "type": "integer"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockMovement"
+                    }
+                },
+                "selisih": {
+                    "description": "Stok - StokLedger",
+                    "type": "integer"
+                },
+                "stok": {
+                    "description": "Stok pada data komik",
+                    "type": "integer"
+                },
+                "stok_ledger": {
+                    "description": "Jumlah delta seluruh catatan perubahan stok",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.StockMovementInput": {
+            "type": "object",
+            "required": [
+                "delta"
+            ],
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "enum": [
+                        "restock",
+                        "sale",
+                        "adjustment",
+                        "return"
+                    ]
+                },
+                "delta": {
+                    "description": "Positif untuk restock/return, negatif untuk sale",
+                    "type": "integer"
+                },
+                "referensi": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "controllers.WebhookInput": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "aktif": {
+                    "description": "Default true",
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Wajib saat membuat webhook, kosongkan saat mengubah untuk memakai secret lama",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "controllers.WishlistInput": {
+            "type": "object",
+            "required": [
+                "komik_id"
+            ],
+            "properties": {
+                "komik_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "inventory.Rekonsiliasi": {
+            "type": "object",
+            "properties": {
+                "komik_id": {
+                    "type": "integer"
+                },
+                "selisih": {
+                    "description": "Stok - StokLedger",
+                    "type": "integer"
+                },
+                "stok": {
+                    "description": "Stok pada data komik",
+                    "type": "integer"
+                },
+                "stok_ledger": {
+                    "description": "Jumlah delta seluruh catatan perubahan stok",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "aksi": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entitas": {
+                    "description": "Misalnya komik, comment atau diskon",
+                    "type": "string"
+                },
+                "entitas_id": {
+                    "description": "0 jika aksi tidak untuk satu data",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "perubahan": {
+                    "type": "object"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "Pelaku, diambil dari token",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Author": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Chapter": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "judul": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nomor": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volume_id": {
+                    "description": "Relasi ke Volume",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Comment": {
+            "type": "object",
+            "required": [
+                "komik_id"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "Terisi jika komentar pernah diedit",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "komentar": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "komik_id": {
+                    "description": "Relasi ke Komik",
+                    "type": "integer"
+                },
+                "reaksi": {
+                    "description": "Jumlah reaksi per jenis, diisi saat komentar ditampilkan",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reaksi_saya": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_reaksi": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "Relasi ke User",
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Bertambah setiap kali data diubah",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CommentRevision": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "description": "Relasi ke Comment",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "editor_id": {
+                    "description": "User yang melakukan edit",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "komentar": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Diskon": {
+            "type": "object",
+            "required": [
+                "berakhir",
+                "mulai"
+            ],
+            "properties": {
+                "berakhir": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "genre_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "jenis": {
+                    "type": "string",
+                    "enum": [
+                        "persen",
+                        "nominal"
+                    ]
+                },
+                "komik_id": {
+                    "type": "integer"
+                },
+                "mata_uang": {
+                    "description": "Hanya untuk diskon nominal, berlaku pada komik dengan mata uang yang sama",
+                    "type": "string"
+                },
+                "mulai": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nilai": {
+                    "description": "Persen (1-100) atau potongan dalam satuan terkecil mata uang",
+                    "type": "integer"
+                },
+                "publisher_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Komik": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Nama author, disalin dari tabel authors",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "author_id": {
+                    "description": "Relasi ke Author",
+                    "type": "integer"
+                },
+                "cover_url": {
+                    "description": "URL cover diisi media.GormPlugin, tambahkan ?ukuran=kecil|sedang|besar untuk thumbnail",
+                    "type": "string"
                 },
-                "komik_id": {
-                    "description": "Relasi ke Komik",
+                "created_at": {
+                    "type": "string"
+                },
+                "genre": {
+                    "description": "Daftar genre dipisah koma, disalin dari tabel genres",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "genre_ids": {
+                    "description": "Hanya dipakai saat input untuk memilih genre",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
+                "harga": {
+                    "description": "Harga dalam satuan terkecil mata uang",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isbn10": {
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "mata_uang": {
+                    "description": "Kode mata uang ISO 4217",
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "publisher": {
+                    "description": "Nama publisher, disalin dari tabel publishers",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "publisher_id": {
+                    "description": "Relasi ke Publisher",
+                    "type": "integer"
+                },
+                "reorder_level": {
+                    "description": "Peringatan stok rendah dikirim jika stok di bawah nilai ini",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stok": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stok_tersedia": {
+                    "description": "Stok dikurangi reservasi yang masih aktif",
                     "type": "integer"
                 },
+                "tahun_terbit": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Bertambah setiap kali data diubah",
+                    "type": "integer"
+                },
+                "volume": {
+                    "description": "Series dan nomor volume komik ini",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Volume"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "read_at": {
+                    "description": "nil jika belum dibaca",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
                 "user_id": {
-                    "description": "Relasi ke User",
                     "type": "integer"
                 }
             }
         },
-        "models.Komik": {
+        "models.Publisher": {
             "type": "object",
             "properties": {
-                "author": {
+                "created_at": {
                     "type": "string"
                 },
-                "genre": {
+                "id": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Reservation": {
+            "type": "object",
+            "properties": {
+                "berakhir": {
+                    "description": "Stok dilepas otomatis setelah waktu ini",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "jumlah": {
+                    "type": "integer"
+                },
+                "komik_id": {
+                    "type": "integer"
+                },
+                "referensi": {
+                    "description": "Nomor pesanan saat dikonfirmasi",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Series": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
+                "deskripsi": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
                 "id": {
                     "type": "integer"
                 },
                 "nama": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "publisher": {
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Volume"
+                    }
+                }
+            }
+        },
+        "models.StockMovement": {
+            "type": "object",
+            "properties": {
+                "alasan": {
                     "type": "string"
                 },
-                "stok": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "description": "Positif jika stok bertambah, negatif jika berkurang",
                     "type": "integer"
                 },
-                "tahun_terbit": {
+                "id": {
+                    "type": "integer"
+                },
+                "komik_id": {
+                    "type": "integer"
+                },
+                "referensi": {
+                    "description": "Misalnya nomor pesanan atau nama file impor",
+                    "type": "string"
+                },
+                "stok_setelah": {
+                    "description": "Stok komik setelah perubahan ini",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "User yang melakukan perubahan, nil jika oleh sistem",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Volume": {
+            "type": "object",
+            "required": [
+                "komik_id"
+            ],
+            "properties": {
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Chapter"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "judul": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "komik": {
+                    "$ref": "#/definitions/models.Komik"
+                },
+                "komik_id": {
+                    "description": "Relasi ke Komik",
+                    "type": "integer"
+                },
+                "nomor": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "series_id": {
+                    "description": "Relasi ke Series",
+                    "type": "integer"
+                },
+                "tersedia": {
+                    "description": "Tersedia bernilai true jika stok komik volume ini masih ada, diisi saat volume ditampilkan",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "aktif": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "berikutnya_pada": {
+                    "description": "nil jika sudah selesai",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "percobaan": {
+                    "type": "integer"
+                },
+                "redelivery_of": {
+                    "description": "Pengiriman asal jika dikirim ulang oleh admin",
+                    "type": "integer"
+                },
+                "respons": {
+                    "description": "Potongan body respons terakhir",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "Status HTTP dari percobaan terakhir, 0 jika tidak ada respons",
+                    "type": "integer"
+                },
+                "terkirim_pada": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Wishlist": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "komik": {
+                    "$ref": "#/definitions/models.Komik"
+                },
+                "komik_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pricing.Baris": {
+            "type": "object",
+            "properties": {
+                "diskon": {
+                    "description": "Diskon yang dipakai",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Diskon"
+                        }
+                    ]
+                },
+                "harga_satuan": {
+                    "description": "Harga sebelum diskon",
+                    "type": "integer"
+                },
+                "jumlah": {
+                    "type": "integer"
+                },
+                "komik_id": {
+                    "type": "integer"
+                },
+                "mata_uang": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "potongan": {
+                    "description": "Total potongan untuk baris ini",
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "description": "HargaSatuan x Jumlah",
+                    "type": "integer"
+                },
+                "tersedia": {
+                    "description": "Stok mencukupi untuk jumlah yang diminta",
+                    "type": "boolean"
+                },
+                "total": {
+                    "description": "Subtotal - Potongan",
+                    "type": "integer"
+                }
+            }
+        },
+        "pricing.Item": {
+            "type": "object",
+            "required": [
+                "jumlah",
+                "komik_id"
+            ],
+            "properties": {
+                "jumlah": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1
+                },
+                "komik_id": {
                     "type": "integer"
                 }
             }
+        },
+        "pricing.Penawaran": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pricing.Baris"
+                    }
+                },
+                "total": {
+                    "description": "Total per mata uang",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "waktu": {
+                    "description": "Diskon dipilih berdasarkan waktu ini",
+                    "type": "string"
+                }
+            }
+        },
+        "websocket.Event": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "type": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -382,7 +4517,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8081",
+	Host:             "localhost:8080",
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Komik API",
@@ -0,0 +1,160 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+
+	"backend/metrics"
+	"backend/models"
+	"backend/outbox"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrStokTidakCukup dikembalikan jika perubahan stok membuat stok menjadi negatif
+	ErrStokTidakCukup = errors.New("stok tidak mencukupi")
+	// ErrKomikTidakDitemukan dikembalikan jika komik yang diubah stoknya tidak ada
+	ErrKomikTidakDitemukan = errors.New("komik tidak ditemukan")
+)
+
+// Record mengubah stok komik sebesar movement.Delta dan mencatatnya. Stok diubah langsung
+// di database sehingga aman dipakai bersamaan oleh beberapa request, dan versi komik
+// dinaikkan agar ETag yang lama tidak berlaku lagi. StokSetelah diisi dari stok terbaru.
+// Perubahan juga dikirim ke webhook sebagai event komik.stock_changed
+func Record(tx *gorm.DB, movement *models.StockMovement) error {
+	if !models.AlasanStokValid(movement.Alasan) {
+		return fmt.Errorf("alasan perubahan stok %q tidak dikenal", movement.Alasan)
+	}
+
+	result := tx.Model(&models.Komik{}).
+		Where("id = ? AND stok + ? >= 0", movement.KomikID, movement.Delta).
+		Updates(map[string]interface{}{
+			"stok":    gorm.Expr("stok + ?", movement.Delta),
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := tx.Model(&models.Komik{}).Where("id = ?", movement.KomikID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrKomikTidakDitemukan
+		}
+		return ErrStokTidakCukup
+	}
+
+	if err := tx.Model(&models.Komik{}).Select("stok").Where("id = ?", movement.KomikID).Scan(&movement.StokSetelah).Error; err != nil {
+		return err
+	}
+	if err := Log(tx, movement); err != nil {
+		return err
+	}
+	return outbox.Webhook(tx, models.EventKomikStockChanged, movement)
+}
+
+// Log mencatat perubahan stok yang sudah disimpan oleh pemanggil, misalnya saat stok
+// diganti lewat PUT dengan pengecekan versi. StokSetelah harus sudah diisi. Jumlah perubahan
+// dicatat ke Metrics di context tx (lihat metrics.NewContext)
+func Log(tx *gorm.DB, movement *models.StockMovement) error {
+	if movement.Delta == 0 {
+		return nil
+	}
+	if !models.AlasanStokValid(movement.Alasan) {
+		return fmt.Errorf("alasan perubahan stok %q tidak dikenal", movement.Alasan)
+	}
+	movement.ID = 0
+	if err := tx.Create(movement).Error; err != nil {
+		return err
+	}
+	if m := metrics.FromContext(tx.Statement.Context); m != nil {
+		m.StockChanges.WithLabelValues(movement.Alasan).Inc()
+	}
+	return nil
+}
+
+// BackInStock memeriksa apakah perubahan stok membuat komik yang habis tersedia kembali
+func BackInStock(movement *models.StockMovement) bool {
+	return movement.Delta > 0 && movement.StokSetelah > 0 && movement.StokSetelah-movement.Delta <= 0
+}
+
+// Rekonsiliasi membandingkan stok komik dengan jumlah seluruh catatan perubahan stoknya
+type Rekonsiliasi struct {
+	KomikID    uint `json:"komik_id"`
+	Stok       int  `json:"stok"`        // Stok pada data komik
+	StokLedger int  `json:"stok_ledger"` // Jumlah delta seluruh catatan perubahan stok
+	Selisih    int  `json:"selisih"`     // Stok - StokLedger
+}
+
+// Check menghitung rekonsiliasi stok untuk satu komik
+func Check(tx *gorm.DB, komikID uint) (Rekonsiliasi, error) {
+	hasil := Rekonsiliasi{KomikID: komikID}
+	if err := tx.Model(&models.Komik{}).Select("stok").Where("id = ?", komikID).Scan(&hasil.Stok).Error; err != nil {
+		return hasil, err
+	}
+	if err := tx.Model(&models.StockMovement{}).Select("COALESCE(SUM(delta), 0)").Where("komik_id = ?", komikID).Scan(&hasil.StokLedger).Error; err != nil {
+		return hasil, err
+	}
+	hasil.Selisih = hasil.Stok - hasil.StokLedger
+	return hasil, nil
+}
+
+// Drift mencari semua komik yang stoknya berbeda dengan jumlah catatan perubahan stoknya
+// tanpa mengubah data apa pun
+func Drift(tx *gorm.DB) ([]Rekonsiliasi, error) {
+	hasil := []Rekonsiliasi{}
+	err := tx.Table("komiks").
+		Select("komiks.id AS komik_id, komiks.stok, COALESCE(SUM(stock_movements.delta), 0) AS stok_ledger").
+		Joins("LEFT JOIN stock_movements ON stock_movements.komik_id = komiks.id").
+		Group("komiks.id, komiks.stok").
+		Having("komiks.stok <> COALESCE(SUM(stock_movements.delta), 0)").
+		Order("komiks.id").
+		Scan(&hasil).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range hasil {
+		hasil[i].Selisih = hasil[i].Stok - hasil[i].StokLedger
+	}
+	return hasil, nil
+}
+
+// Reconcile mencari komik yang stoknya berbeda dengan catatan perubahan stok, lalu
+// menambahkan catatan adjustment sebesar selisihnya. Stok komik tidak diubah karena
+// stok tersebut yang dipakai saat transaksi. Dipakai juga sekali saat migrasi untuk
+// membuat saldo awal komik yang sudah ada sebelum catatan perubahan stok diperkenalkan
+func Reconcile(tx *gorm.DB, userID *uint, referensi string) ([]Rekonsiliasi, error) {
+	hasil, err := Drift(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range hasil {
+		err := Log(tx, &models.StockMovement{
+			KomikID:     hasil[i].KomikID,
+			Delta:       hasil[i].Selisih,
+			Alasan:      models.AlasanAdjustment,
+			UserID:      userID,
+			Referensi:   referensi,
+			StokSetelah: hasil[i].Stok,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hasil, nil
+}
+
+// LowStock mengembalikan komik yang stoknya di bawah reorder level, diurutkan dari
+// yang paling jauh di bawah reorder level. Komik dengan reorder level 0 tidak pernah
+// termasuk karena stok tidak dapat negatif
+func LowStock(tx *gorm.DB) ([]models.Komik, error) {
+	komiks := []models.Komik{}
+	err := tx.Where("stok < reorder_level").
+		Order("stok - reorder_level, nama").
+		Find(&komiks).Error
+	return komiks, err
+}
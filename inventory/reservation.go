@@ -0,0 +1,169 @@
+package inventory
+
+import (
+	"errors"
+	"time"
+
+	"backend/models"
+	"backend/outbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrStokTidakTersedia dikembalikan jika stok dikurangi reservasi user lain tidak mencukupi
+	ErrStokTidakTersedia = errors.New("stok yang tersedia tidak mencukupi")
+	// ErrReservasiTidakAktif dikembalikan jika reservasi sudah dikonfirmasi, dilepas atau kedaluwarsa
+	ErrReservasiTidakAktif = errors.New("reservasi sudah tidak aktif")
+)
+
+// Reserved menghitung stok yang sedang ditahan reservasi aktif untuk setiap komik. Reservasi
+// milik exceptUserID tidak dihitung (isi 0 untuk menghitung semua reservasi). Reservasi
+// yang sudah lewat waktunya tidak dihitung meskipun belum dilepas oleh sweeper
+func Reserved(tx *gorm.DB, komikIDs []uint, exceptUserID uint, now time.Time) (map[uint]int, error) {
+	var rows []struct {
+		KomikID uint
+		Jumlah  int
+	}
+	err := tx.Model(&models.Reservation{}).
+		Select("komik_id, SUM(jumlah) AS jumlah").
+		Where("komik_id IN ? AND status = ? AND berakhir > ? AND user_id <> ?", komikIDs, models.ReservasiAktif, now, exceptUserID).
+		Group("komik_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		reserved[row.KomikID] = row.Jumlah
+	}
+	return reserved, nil
+}
+
+// CheckAvailable mengunci data komik sampai transaksi selesai lalu memastikan stok dikurangi
+// reservasi user lain masih cukup untuk jumlah yang diminta. Dipanggil sebelum membuat
+// reservasi atau menjual komik agar stok yang ditahan tidak dapat dibeli user lain. userID
+// harus berasal dari user yang login (0 jika tanpa login, semua reservasi dihitung), bukan
+// dari isi request
+func CheckAvailable(tx *gorm.DB, komikID, userID uint, jumlah int, now time.Time) error {
+	var komik models.Komik
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stok").First(&komik, komikID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrKomikTidakDitemukan
+	}
+	if err != nil {
+		return err
+	}
+
+	reserved, err := Reserved(tx, []uint{komikID}, userID, now)
+	if err != nil {
+		return err
+	}
+	if komik.Stok-reserved[komikID] < jumlah {
+		return ErrStokTidakTersedia
+	}
+	return nil
+}
+
+// Reserve menahan stok komik untuk user selama ttl. Jika user sudah memiliki reservasi aktif
+// untuk komik yang sama, jumlahnya diganti dan waktunya diperpanjang. Keduanya dikirim ke
+// webhook sebagai event order.reserved
+func Reserve(tx *gorm.DB, userID, komikID uint, jumlah int, ttl time.Duration, now time.Time) (*models.Reservation, error) {
+	if err := CheckAvailable(tx, komikID, userID, jumlah, now); err != nil {
+		return nil, err
+	}
+
+	var reservation models.Reservation
+	result := tx.Where("komik_id = ? AND user_id = ? AND status = ? AND berakhir > ?", komikID, userID, models.ReservasiAktif, now).
+		Limit(1).Find(&reservation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		reservation = models.Reservation{KomikID: komikID, UserID: userID, Status: models.ReservasiAktif}
+	}
+	reservation.Jumlah = jumlah
+	reservation.Berakhir = now.Add(ttl)
+	if err := tx.Save(&reservation).Error; err != nil {
+		return nil, err
+	}
+	if err := outbox.Order(tx, reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// Release melepas reservasi aktif dengan status dilepas atau kedaluwarsa
+func Release(tx *gorm.DB, reservation *models.Reservation, status string) error {
+	return setStatus(tx, reservation, status, reservation.Referensi, time.Time{})
+}
+
+// Confirm mengubah reservasi menjadi penjualan: stok komik dikurangi sebesar jumlah
+// reservasi dan dicatat sebagai sale dengan referensi pesanan. Reservasi yang sudah lewat
+// waktunya tidak dapat dikonfirmasi
+func Confirm(tx *gorm.DB, reservation *models.Reservation, referensi string, now time.Time) error {
+	if err := setStatus(tx, reservation, models.ReservasiDikonfirmasi, referensi, now); err != nil {
+		return err
+	}
+	userID := reservation.UserID
+	return Record(tx, &models.StockMovement{
+		KomikID:   reservation.KomikID,
+		Delta:     -reservation.Jumlah,
+		Alasan:    models.AlasanSale,
+		UserID:    &userID,
+		Referensi: referensi,
+	})
+}
+
+// ExpireReservations menandai reservasi aktif yang sudah lewat waktunya sebagai kedaluwarsa
+// dan mengembalikan reservasi tersebut. Sebaiknya dipanggil di dalam transaksi agar
+// reservasi yang dikunci tidak dilepas bersamaan oleh request lain
+func ExpireReservations(tx *gorm.DB, now time.Time) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ? AND berakhir <= ?", models.ReservasiAktif, now).
+		Order("id").
+		Find(&reservations).Error
+	if err != nil || len(reservations) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, len(reservations))
+	for i := range reservations {
+		ids[i] = reservations[i].ID
+		reservations[i].Status = models.ReservasiKedaluwarsa
+	}
+	err = tx.Model(&models.Reservation{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"status": models.ReservasiKedaluwarsa, "updated_at": now}).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := outbox.Order(tx, reservations...); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// setStatus mengubah status reservasi yang masih aktif. Jika activeAt diisi, reservasi
+// juga harus belum lewat waktunya pada saat tersebut. Perubahan dilakukan dengan kondisi
+// status aktif sehingga reservasi tidak dapat dikonfirmasi atau dilepas dua kali. Status
+// baru dikirim ke webhook sebagai event pesanan
+func setStatus(tx *gorm.DB, reservation *models.Reservation, status, referensi string, activeAt time.Time) error {
+	query := tx.Model(reservation).Where("status = ?", models.ReservasiAktif)
+	if !activeAt.IsZero() {
+		query = query.Where("berakhir > ?", activeAt)
+	}
+	result := query.Updates(map[string]interface{}{"status": status, "referensi": referensi})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReservasiTidakAktif
+	}
+	reservation.Status = status
+	reservation.Referensi = referensi
+	return outbox.Order(tx, *reservation)
+}
@@ -0,0 +1,117 @@
+package isbn
+
+import (
+	"strings"
+)
+
+// Normalize menghapus tanda hubung dan spasi serta menyeragamkan huruf X pada ISBN,
+// misalnya "4-08-872509-x" menjadi "408872509X"
+func Normalize(value string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(value)))
+}
+
+// Valid10 memeriksa panjang dan checksum ISBN-10 yang sudah dinormalisasi
+func Valid10(value string) bool {
+	if len(value) != 10 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		digit, ok := digitValue(value[i], i == 9)
+		if !ok {
+			return false
+		}
+		sum += (i + 1) * digit
+	}
+	return sum%11 == 0
+}
+
+// Valid13 memeriksa panjang dan checksum ISBN-13 yang sudah dinormalisasi
+func Valid13(value string) bool {
+	if len(value) != 13 {
+		return false
+	}
+	for i := 0; i < 13; i++ {
+		if _, ok := digitValue(value[i], false); !ok {
+			return false
+		}
+	}
+	return value[12] == checkDigit13(value[:12])
+}
+
+// To13 mengubah ISBN-10 menjadi ISBN-13 dengan awalan 978
+func To13(isbn10 string) string {
+	if !Valid10(isbn10) {
+		return ""
+	}
+	body := "978" + isbn10[:9]
+	return body + string(checkDigit13(body))
+}
+
+// To10 mengubah ISBN-13 berawalan 978 menjadi ISBN-10. ISBN-13 berawalan 979
+// tidak memiliki padanan ISBN-10 sehingga menghasilkan string kosong
+func To10(isbn13 string) string {
+	if !Valid13(isbn13) || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+	body := isbn13[3:12]
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (i + 1) * int(body[i]-'0')
+	}
+	check := sum % 11
+	if check == 10 {
+		return body + "X"
+	}
+	return body + string(rune('0'+check))
+}
+
+// Complete menormalisasi pasangan ISBN-10 dan ISBN-13 lalu melengkapi yang kosong
+// dari pasangannya jika memungkinkan
+func Complete(isbn10, isbn13 *string) (*string, *string) {
+	isbn10 = normalizePtr(isbn10)
+	isbn13 = normalizePtr(isbn13)
+	if isbn13 == nil && isbn10 != nil {
+		isbn13 = nonEmpty(To13(*isbn10))
+	}
+	if isbn10 == nil && isbn13 != nil {
+		isbn10 = nonEmpty(To10(*isbn13))
+	}
+	return isbn10, isbn13
+}
+
+func checkDigit13(body string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(body[i]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func digitValue(c byte, allowX bool) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c == 'X' && allowX:
+		return 10, true
+	}
+	return 0, false
+}
+
+func normalizePtr(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	return nonEmpty(Normalize(*value))
+}
+
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
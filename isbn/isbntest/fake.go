@@ -0,0 +1,41 @@
+// Package isbntest berisi MetadataProvider palsu untuk pengujian tanpa akses internet
+package isbntest
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"backend/isbn"
+)
+
+//go:embed testdata/books.json
+var fixtureBooks []byte
+
+// Fake adalah isbn.MetadataProvider yang membaca data buku dari fixture JSON. Key map
+// adalah ISBN-10 atau ISBN-13
+type Fake struct {
+	Books map[string]isbn.Metadata
+}
+
+// NewFake membuat Fake dengan fixture bawaan (testdata/books.json)
+func NewFake() *Fake {
+	fake := &Fake{}
+	if err := json.Unmarshal(fixtureBooks, &fake.Books); err != nil {
+		panic("isbntest: fixture bawaan tidak valid: " + err.Error())
+	}
+	return fake
+}
+
+// Lookup mencari buku pada fixture berdasarkan ISBN-10 atau ISBN-13
+func (f *Fake) Lookup(ctx context.Context, value string) (*isbn.Metadata, error) {
+	if metadata, ok := f.Books[value]; ok {
+		return &metadata, nil
+	}
+	for _, metadata := range f.Books {
+		if metadata.ISBN10 == value || metadata.ISBN13 == value {
+			return &metadata, nil
+		}
+	}
+	return nil, isbn.ErrNotFound
+}
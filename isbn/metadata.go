@@ -0,0 +1,26 @@
+package isbn
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound dikembalikan jika provider tidak memiliki data untuk ISBN yang dicari
+var ErrNotFound = errors.New("data buku tidak ditemukan")
+
+// Metadata adalah data buku dari provider yang dapat dipakai untuk mengisi form komik.
+// Nama field JSON sama dengan field komik
+type Metadata struct {
+	ISBN10      string `json:"isbn10,omitempty"`
+	ISBN13      string `json:"isbn13,omitempty"`
+	Nama        string `json:"nama"`
+	Author      string `json:"author"`
+	Publisher   string `json:"publisher"`
+	Genre       string `json:"genre,omitempty"`
+	TahunTerbit int    `json:"tahun_terbit,omitempty"`
+}
+
+// MetadataProvider mencari data buku berdasarkan ISBN-10 atau ISBN-13 yang sudah dinormalisasi
+type MetadataProvider interface {
+	Lookup(ctx context.Context, isbn string) (*Metadata, error)
+}
@@ -0,0 +1,120 @@
+package isbn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenLibraryURL adalah alamat API Open Library
+const OpenLibraryURL = "https://openlibrary.org"
+
+var tahunPattern = regexp.MustCompile(`\b\d{4}\b`)
+
+// OpenLibrary mengambil data buku dari API Books milik Open Library
+// (atau server lain dengan format response yang sama)
+type OpenLibrary struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOpenLibrary membuat provider Open Library. baseURL kosong berarti OpenLibraryURL
+func NewOpenLibrary(baseURL string) *OpenLibrary {
+	if baseURL == "" {
+		baseURL = OpenLibraryURL
+	}
+	return &OpenLibrary{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// openLibraryBook adalah bagian response /api/books?jscmd=data yang dipakai
+type openLibraryBook struct {
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	PublishDate string `json:"publish_date"`
+	Authors     []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Publishers []struct {
+		Name string `json:"name"`
+	} `json:"publishers"`
+	Subjects []struct {
+		Name string `json:"name"`
+	} `json:"subjects"`
+	Identifiers struct {
+		ISBN10 []string `json:"isbn_10"`
+		ISBN13 []string `json:"isbn_13"`
+	} `json:"identifiers"`
+}
+
+// Lookup mencari buku berdasarkan ISBN
+func (p *OpenLibrary) Lookup(ctx context.Context, isbn string) (*Metadata, error) {
+	bibkey := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {bibkey}, "format": {"json"}, "jscmd": {"data"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("open library merespons %s", resp.Status)
+	}
+
+	var books map[string]openLibraryBook
+	if err := json.NewDecoder(resp.Body).Decode(&books); err != nil {
+		return nil, err
+	}
+	book, ok := books[bibkey]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return book.metadata(), nil
+}
+
+func (b openLibraryBook) metadata() *Metadata {
+	metadata := &Metadata{Nama: b.Title}
+	if b.Subtitle != "" {
+		metadata.Nama += ": " + b.Subtitle
+	}
+
+	var authors []string
+	for _, author := range b.Authors {
+		authors = append(authors, author.Name)
+	}
+	metadata.Author = strings.Join(authors, ", ")
+	if len(b.Publishers) > 0 {
+		metadata.Publisher = b.Publishers[0].Name
+	}
+
+	// Subjek Open Library bisa sangat banyak, ambil beberapa yang pertama sebagai genre
+	var subjects []string
+	for i := 0; i < len(b.Subjects) && i < 3; i++ {
+		subjects = append(subjects, b.Subjects[i].Name)
+	}
+	metadata.Genre = strings.Join(subjects, ", ")
+
+	if tahun := tahunPattern.FindString(b.PublishDate); tahun != "" {
+		metadata.TahunTerbit, _ = strconv.Atoi(tahun)
+	}
+	if len(b.Identifiers.ISBN10) > 0 {
+		metadata.ISBN10 = Normalize(b.Identifiers.ISBN10[0])
+	}
+	if len(b.Identifiers.ISBN13) > 0 {
+		metadata.ISBN13 = Normalize(b.Identifiers.ISBN13[0])
+	}
+	return metadata
+}
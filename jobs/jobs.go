@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// every menjalankan fn segera lalu setiap interval sampai ctx dibatalkan. Error dari fn
+// hanya dicatat di log agar pekerjaan tetap berjalan pada putaran berikutnya
+func every(ctx context.Context, interval time.Duration, nama string, fn func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			slog.ErrorContext(ctx, "Gagal "+nama, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"backend/alerts"
+	"backend/inventory"
+	"backend/models"
+	"backend/outbox"
+	"backend/websocket"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LowStockChecker memeriksa secara berkala komik yang stoknya di bawah reorder level,
+// lalu mengirim event stock_low ke klien WebSocket dan peringatan ke notifier.
+// Peringatan untuk satu komik hanya dikirim sekali sampai stoknya kembali mencapai
+// reorder level. Komik yang sudah diberi peringatan dicatat di tabel low_stock_alerts,
+// sehingga peringatan hanya dikirim oleh satu server dan tidak diulang setelah server
+// dijalankan ulang. Event stock_low disimpan di outbox bersama catatan tersebut agar
+// diterima klien di semua server
+type LowStockChecker struct {
+	DB       *gorm.DB
+	Notifier alerts.Notifier
+	Interval time.Duration
+}
+
+// NewLowStockChecker membuat pemeriksa stok rendah
+func NewLowStockChecker(db *gorm.DB, notifier alerts.Notifier, interval time.Duration) *LowStockChecker {
+	return &LowStockChecker{DB: db, Notifier: notifier, Interval: interval}
+}
+
+// Run menjalankan pemeriksaan setiap Interval sampai ctx dibatalkan
+func (c *LowStockChecker) Run(ctx context.Context) {
+	every(ctx, c.Interval, "memeriksa stok rendah", c.Check)
+}
+
+// Check memeriksa stok semua komik satu kali dan mengirim peringatan untuk komik
+// yang baru turun di bawah reorder level
+func (c *LowStockChecker) Check(ctx context.Context) error {
+	db := c.DB.WithContext(ctx)
+	komiks, err := inventory.LowStock(db)
+	if err != nil {
+		return err
+	}
+
+	// Komik yang stoknya sudah kembali normal akan diberi peringatan lagi jika turun lagi
+	ids := make([]uint, len(komiks))
+	for i, komik := range komiks {
+		ids[i] = komik.ID
+	}
+	normal := db.Session(&gorm.Session{AllowGlobalUpdate: true})
+	if len(ids) > 0 {
+		normal = normal.Where("komik_id NOT IN ?", ids)
+	}
+	if err := normal.Delete(&models.LowStockAlert{}).Error; err != nil {
+		return err
+	}
+
+	for _, komik := range komiks {
+		data := alerts.StockLow{KomikID: komik.ID, Nama: komik.Nama, Stok: komik.Stok, ReorderLevel: komik.ReorderLevel}
+		baru := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Hanya server yang berhasil mencatat peringatan yang mengirimnya
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.LowStockAlert{KomikID: komik.ID})
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			baru = true
+			return outbox.Broadcast(tx, websocket.EventStockLow, data)
+		})
+		if err != nil {
+			return err
+		}
+		if baru && c.Notifier != nil {
+			if err := c.Notifier.Notify(ctx, alerts.NewStockLow(data, time.Now())); err != nil {
+				slog.ErrorContext(ctx, "Gagal mengirim peringatan stok rendah", "error", err)
+			}
+		}
+	}
+	return nil
+}
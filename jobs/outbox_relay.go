@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/tracing"
+	"backend/webhook"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// relayBatch adalah jumlah maksimal event outbox yang diteruskan dalam satu putaran
+const relayBatch = 100
+
+// OutboxRelay secara berkala memindahkan event webhook di outbox ke antrean pengiriman
+// webhook, berurutan sesuai ID. Event ditandai dengan published_at dalam transaksi yang sama
+// dengan pembuatan pengirimannya, sehingga event tidak pernah hilang tetapi dapat diteruskan
+// lebih dari sekali jika relay berhenti di tengah jalan (at-least-once). Event realtime
+// diteruskan oleh RealtimeRelay milik setiap Hub
+type OutboxRelay struct {
+	DB       *gorm.DB
+	Interval time.Duration
+	Retensi  time.Duration // Lama event disimpan sebelum dihapus
+}
+
+// NewOutboxRelay membuat relay yang meneruskan event webhook di outbox
+func NewOutboxRelay(db *gorm.DB, interval, retensi time.Duration) *OutboxRelay {
+	return &OutboxRelay{DB: db, Interval: interval, Retensi: retensi}
+}
+
+// Run menjalankan Relay setiap Interval sampai ctx dibatalkan
+func (r *OutboxRelay) Run(ctx context.Context) {
+	every(ctx, r.Interval, "meneruskan event outbox", r.Relay)
+}
+
+// Relay meneruskan semua event webhook yang belum diteruskan lalu menghapus event lama yang
+// sudah melewati masa retensi. Event realtime tidak ditandai terkirim sehingga dihapus
+// berdasarkan waktu pembuatannya
+func (r *OutboxRelay) Relay(ctx context.Context) error {
+	for {
+		n, err := r.relayBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if n < relayBatch {
+			break
+		}
+	}
+	batas := time.Now().Add(-r.Retensi)
+	return r.DB.WithContext(ctx).
+		Where("channel = ? AND published_at < ?", models.OutboxWebhook, batas).
+		Or("channel = ? AND created_at < ?", models.OutboxRealtime, batas).
+		Delete(&models.OutboxEvent{}).Error
+}
+
+// relayBatch meneruskan satu kelompok event webhook yang belum diteruskan lalu mengembalikan
+// jumlahnya. Event dikunci selama transaksi agar tidak diteruskan bersamaan oleh server lain.
+// Penguncian juga menunggu event yang masih disimpan oleh transaksi yang belum selesai,
+// sehingga event dengan ID lebih kecil tidak terlewat oleh event setelahnya
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	var events []models.OutboxEvent
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("channel = ? AND published_at IS NULL", models.OutboxWebhook).
+			Order("id").
+			Limit(relayBatch).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(events))
+		for i, event := range events {
+			err := publish(tx.Statement.Context, event, func(ctx context.Context) error {
+				return webhook.Enqueue(tx.WithContext(ctx), event.Type, event.CreatedAt, event.Payload)
+			})
+			if err != nil {
+				return err
+			}
+			ids[i] = event.ID
+		}
+		return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Update("published_at", time.Now()).Error
+	})
+	return len(events), err
+}
+
+// publish menjalankan fn untuk meneruskan satu event. Span-nya menjadi bagian dari trace
+// request yang membuat event, sehingga jeda sampai event diteruskan terlihat di trace tersebut
+func publish(ctx context.Context, event models.OutboxEvent, fn func(context.Context) error) error {
+	ctx, span := tracing.Tracer().Start(tracing.Extract(ctx, event.TraceParent), "outbox.publish "+event.Type,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.Int64("outbox.event_id", int64(event.ID)),
+			attribute.String("outbox.channel", event.Channel),
+		),
+	)
+	defer span.End()
+
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
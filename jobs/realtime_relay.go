@@ -0,0 +1,139 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/websocket"
+
+	"gorm.io/gorm"
+)
+
+// batasTerlambat adalah lama ID yang dilewati RealtimeRelay tetap diperiksa. Event dengan ID
+// tersebut yang baru tersimpan dalam waktu ini tetap dikirim walaupun tidak lagi berurutan
+const batasTerlambat = time.Minute
+
+// RealtimeRelay secara berkala mengirim event realtime di outbox ke klien yang terhubung ke
+// Hub, berurutan sesuai ID. Setiap Hub memiliki RealtimeRelay sendiri yang menyimpan posisi
+// event terakhir di memori, sehingga setiap server mengirim semua event ke kliennya sendiri.
+// Posisi awal adalah event terbaru saat relay dimulai, karena belum ada klien yang terhubung
+// sebelumnya.
+//
+// ID event dibuat saat disimpan, sedangkan transaksinya dapat selesai tidak berurutan. Jika
+// ada ID yang belum terlihat di antara event, relay menunggu sampai TungguCelah sebelum
+// melanjutkan. ID tersebut dianggap milik transaksi yang dibatalkan, tetapi tetap diperiksa
+// selama batasTerlambat dan dikirim jika ternyata tersimpan
+type RealtimeRelay struct {
+	DB          *gorm.DB
+	Hub         *websocket.Hub
+	Interval    time.Duration
+	TungguCelah time.Duration
+
+	posisi uint               // ID event terakhir yang sudah diproses
+	mulai  bool               // posisi sudah diisi
+	celah  map[uint]time.Time // ID yang belum terlihat beserta waktu pertama kali diketahui
+}
+
+// NewRealtimeRelay membuat relay yang mengirim event realtime di outbox ke klien hub
+func NewRealtimeRelay(db *gorm.DB, hub *websocket.Hub, interval, tungguCelah time.Duration) *RealtimeRelay {
+	return &RealtimeRelay{DB: db, Hub: hub, Interval: interval, TungguCelah: tungguCelah, celah: map[uint]time.Time{}}
+}
+
+// Run menjalankan Relay setiap Interval sampai ctx dibatalkan
+func (r *RealtimeRelay) Run(ctx context.Context) {
+	every(ctx, r.Interval, "mengirim event realtime", r.Relay)
+}
+
+// Relay mengirim semua event realtime yang sudah tersimpan setelah posisi terakhir
+func (r *RealtimeRelay) Relay(ctx context.Context) error {
+	db := r.DB.WithContext(ctx)
+	if !r.mulai {
+		if err := db.Model(&models.OutboxEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&r.posisi).Error; err != nil {
+			return err
+		}
+		r.mulai = true
+		return nil
+	}
+
+	if err := r.relayTerlambat(ctx); err != nil {
+		return err
+	}
+	for {
+		var events []models.OutboxEvent
+		if err := db.Where("id > ?", r.posisi).Order("id").Limit(relayBatch).Find(&events).Error; err != nil {
+			return err
+		}
+		if !r.relayBatch(ctx, events) || len(events) < relayBatch {
+			return nil
+		}
+	}
+}
+
+// relayBatch mengirim event berurutan selama tidak ada ID yang terlewat dan memajukan posisi.
+// Mengembalikan false jika relay berhenti untuk menunggu ID yang belum terlihat
+func (r *RealtimeRelay) relayBatch(ctx context.Context, events []models.OutboxEvent) bool {
+	now := time.Now()
+	for _, event := range events {
+		for id := r.posisi + 1; id < event.ID; id++ {
+			sejak, ok := r.celah[id]
+			if !ok {
+				r.celah[id] = now
+				sejak = now
+			}
+			if now.Sub(sejak) < r.TungguCelah {
+				return false
+			}
+			r.posisi = id
+		}
+		r.send(ctx, event)
+		delete(r.celah, event.ID)
+		r.posisi = event.ID
+	}
+	return true
+}
+
+// relayTerlambat mengirim event dengan ID yang sudah dilewati tetapi ternyata tersimpan, lalu
+// berhenti memeriksa ID yang sudah melewati batasTerlambat
+func (r *RealtimeRelay) relayTerlambat(ctx context.Context) error {
+	var ids []uint
+	for id, sejak := range r.celah {
+		switch {
+		case id > r.posisi:
+			// Masih ditunggu oleh relayBatch
+		case time.Since(sejak) > batasTerlambat:
+			delete(r.celah, id)
+		default:
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var events []models.OutboxEvent
+	if err := r.DB.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&events).Error; err != nil {
+		return err
+	}
+	for _, event := range events {
+		r.send(ctx, event)
+		delete(r.celah, event.ID)
+	}
+	return nil
+}
+
+// send mengirim event realtime ke penerimanya. Event webhook hanya memajukan posisi
+func (r *RealtimeRelay) send(ctx context.Context, event models.OutboxEvent) {
+	if event.Channel != models.OutboxRealtime {
+		return
+	}
+	_ = publish(ctx, event, func(ctx context.Context) error {
+		message := websocket.Event{Type: event.Type, Data: event.Payload}
+		if event.UserID != nil {
+			r.Hub.SendToUser(ctx, *event.UserID, message)
+		} else {
+			r.Hub.Broadcast(ctx, message)
+		}
+		return nil
+	})
+}
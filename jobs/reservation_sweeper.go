@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"backend/inventory"
+	"backend/models"
+	"backend/notification"
+
+	"gorm.io/gorm"
+)
+
+// ReservationSweeper secara berkala menandai reservasi stok yang tidak dikonfirmasi sampai
+// waktunya habis sebagai kedaluwarsa. Stok yang ditahan reservasi tersebut sudah tersedia
+// kembali sejak waktunya habis; sweeper hanya merapikan statusnya
+type ReservationSweeper struct {
+	DB       *gorm.DB
+	Interval time.Duration
+}
+
+// NewReservationSweeper membuat sweeper reservasi
+func NewReservationSweeper(db *gorm.DB, interval time.Duration) *ReservationSweeper {
+	return &ReservationSweeper{DB: db, Interval: interval}
+}
+
+// Run menjalankan Sweep setiap Interval sampai ctx dibatalkan
+func (s *ReservationSweeper) Run(ctx context.Context) {
+	every(ctx, s.Interval, "melepas reservasi kedaluwarsa", s.Sweep)
+}
+
+// Sweep melepas semua reservasi yang sudah lewat waktunya dan memberi tahu pemiliknya
+func (s *ReservationSweeper) Sweep(ctx context.Context) error {
+	var expired []models.Reservation
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		expired, err = inventory.ExpireReservations(tx, time.Now())
+		if err != nil {
+			return err
+		}
+		return notification.NotifyOrderStatus(tx, expired...)
+	})
+	if err != nil {
+		return err
+	}
+	if len(expired) > 0 {
+		slog.InfoContext(ctx, "Reservasi stok kedaluwarsa dilepas", "jumlah", len(expired))
+	}
+	return nil
+}
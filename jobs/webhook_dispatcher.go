@@ -0,0 +1,165 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/webhook"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	dispatchBatch    = 100 // Jumlah maksimal pengiriman yang diproses dalam satu putaran
+	dispatchParallel = 10  // Jumlah maksimal webhook yang dikirimi bersamaan
+)
+
+// WebhookDispatcher secara berkala mengirim pengiriman webhook yang sudah waktunya dikirim.
+// Pengiriman yang gagal dicoba ulang dengan jeda yang makin panjang (lihat webhook.Backoff)
+// sampai MaxPercobaan, setelah itu ditandai gagal dan hanya dapat dikirim ulang oleh admin.
+// Beberapa server dapat menjalankan dispatcher bersamaan; setiap pengiriman diklaim lebih
+// dulu sehingga hanya dikirim oleh satu server
+type WebhookDispatcher struct {
+	DB           *gorm.DB
+	Sender       *webhook.Sender
+	Interval     time.Duration
+	MaxPercobaan int
+}
+
+// NewWebhookDispatcher membuat dispatcher webhook
+func NewWebhookDispatcher(db *gorm.DB, sender *webhook.Sender, interval time.Duration, maxPercobaan int) *WebhookDispatcher {
+	return &WebhookDispatcher{DB: db, Sender: sender, Interval: interval, MaxPercobaan: maxPercobaan}
+}
+
+// Run menjalankan Dispatch setiap Interval sampai ctx dibatalkan
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	every(ctx, d.Interval, "mengirim webhook", d.Dispatch)
+}
+
+// Dispatch mengklaim pengiriman yang menunggu dan sudah lewat jadwalnya, dari yang paling
+// lama, lalu mengirimnya. Pengiriman ke webhook yang berbeda dilakukan bersamaan, sedangkan
+// pengiriman ke webhook yang sama tetap berurutan
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) error {
+	deliveries, err := d.claim(ctx)
+	if err != nil || len(deliveries) == 0 {
+		return err
+	}
+
+	ids := make([]uint, 0, len(deliveries))
+	byWebhook := make(map[uint][]*models.WebhookDelivery)
+	for i := range deliveries {
+		id := deliveries[i].WebhookID
+		if _, ok := byWebhook[id]; !ok {
+			ids = append(ids, id)
+		}
+		byWebhook[id] = append(byWebhook[id], &deliveries[i])
+	}
+	var webhooks []models.Webhook
+	if err := d.DB.WithContext(ctx).Where("id IN ?", ids).Find(&webhooks).Error; err != nil {
+		return err
+	}
+	byID := make(map[uint]*models.Webhook, len(webhooks))
+	for i := range webhooks {
+		byID[webhooks[i].ID] = &webhooks[i]
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	slots := make(chan struct{}, dispatchParallel)
+	for _, id := range ids {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(w *models.Webhook, deliveries []*models.WebhookDelivery) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			for _, delivery := range deliveries {
+				err := ctx.Err()
+				if err == nil {
+					err = d.deliver(ctx, w, delivery)
+				}
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					return
+				}
+			}
+		}(byID[id], byWebhook[id])
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// claim mengambil pengiriman yang sudah waktunya dikirim lalu memundurkan jadwalnya di dalam
+// satu transaksi. Baris yang sedang diklaim server lain dilewati, dan pengiriman yang diklaim
+// tidak terlihat menunggu oleh server lain sampai klaimnya habis. Klaim cukup lama untuk
+// mengirim semua pengiriman ke webhook yang sama satu per satu sampai batas waktu sender;
+// jika server berhenti sebelum hasilnya disimpan, pengiriman dicoba lagi setelahnya
+func (d *WebhookDispatcher) claim(ctx context.Context) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND berikutnya_pada <= ?", models.PengirimanMenunggu, now).
+			Order("berikutnya_pada, id").
+			Limit(dispatchBatch).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+		ids := make([]uint, 0, len(deliveries))
+		perWebhook, terbanyak := make(map[uint]int), 0
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+			perWebhook[delivery.WebhookID]++
+			terbanyak = max(terbanyak, perWebhook[delivery.WebhookID])
+		}
+		klaim := time.Duration(terbanyak)*d.Sender.Client.Timeout + d.Interval
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("berikutnya_pada", now.Add(klaim)).Error
+	})
+	return deliveries, err
+}
+
+// deliver mengirim satu pengiriman lalu menyimpan hasil dan jadwal percobaan berikutnya.
+// w bernilai nil jika webhook sudah dihapus
+func (d *WebhookDispatcher) deliver(ctx context.Context, w *models.Webhook, delivery *models.WebhookDelivery) error {
+	now := time.Now()
+	percobaan := delivery.Percobaan + 1
+	updates := map[string]interface{}{"percobaan": percobaan}
+
+	if w == nil || !w.Aktif {
+		updates["status"] = models.PengirimanGagal
+		updates["berikutnya_pada"] = nil
+		updates["error"] = "webhook sudah tidak aktif"
+		return d.DB.WithContext(ctx).Model(delivery).Updates(updates).Error
+	}
+
+	result, err := d.Sender.Send(ctx, *w, *delivery)
+	updates["status_code"] = result.StatusCode
+	updates["respons"] = result.Respons
+	switch {
+	case err == nil:
+		updates["status"] = models.PengirimanTerkirim
+		updates["berikutnya_pada"] = nil
+		updates["terkirim_pada"] = now
+		updates["error"] = ""
+	case percobaan >= d.MaxPercobaan:
+		updates["status"] = models.PengirimanGagal
+		updates["berikutnya_pada"] = nil
+		updates["error"] = err.Error()
+	default:
+		updates["berikutnya_pada"] = now.Add(webhook.Backoff(percobaan))
+		updates["error"] = err.Error()
+	}
+	return d.DB.WithContext(ctx).Model(delivery).Updates(updates).Error
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Nama atribut yang ditambahkan dari context ke setiap log
+const (
+	KeyRequestID = "request_id"
+	KeyTraceID   = "trace_id"
+	KeySpanID    = "span_id"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID menyimpan ID request di context. Log yang ditulis dengan context tersebut,
+// misalnya lewat slog.InfoContext, otomatis menyertakan ID request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID mengembalikan ID request yang tersimpan di context, kosong jika tidak ada
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// New membuat logger JSON yang menulis ke w mulai dari level tertentu
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+// ParseLevel mengubah nama level (debug, info, warn, error) menjadi slog.Level
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	return level, err
+}
+
+// Fatal menulis log error lalu menghentikan program
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// contextHandler menambahkan ID request dan trace dari context ke setiap log, sehingga log
+// dapat dicocokkan dengan span tracing-nya
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(KeyRequestID, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String(KeyTraceID, sc.TraceID().String()), slog.String(KeySpanID, sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -1,16 +1,27 @@
 package main
 
 import (
+	"backend/app"
 	"backend/config"
 	_ "backend/docs"
-	"backend/routes"
-	"log"
+	"backend/logging"
+	"backend/metrics"
+	"backend/validation"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // @title Komik API
@@ -23,42 +34,99 @@ import (
 // @host localhost:8080
 // @BasePath /
 func main() {
-	// Membuat instance Gin
-	router := gin.Default()
-
-	// Nonaktifkan redirect trailing slash
-	router.RedirectTrailingSlash = false
-
-	// Middleware CORS
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:5173", "https://uasfrontend-nine.vercel.app", "https://uas-frontend-qt2c.vercel.app", "https://uas-frontend-final.vercel.app", "https://uas-frontend-6l29.vercel.app"}, // URL frontend
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},                                                                                                                                                          // Metode HTTP yang diizinkan
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},                                                                                                                                     // Header yang diizinkan
-		AllowCredentials: true,                                                                                                                                                                                              // Jika menggunakan cookie atau header Authorization
-		ExposeHeaders:    []string{"Content-Length"},                                                                                                                                                                        // Header yang dapat diakses oleh client
-		MaxAge:           12 * time.Hour,                                                                                                                                                                                    // Cache header selama 12 jam
-	}))
-
-	// Koneksi ke database
-	setupDatabase()
-
-	// Registrasi routes
-	routes.RegisterRoutes(router)
-	routes.RegisterCommentRoutes(router) // Aktifkan rute komentar
-
-	// Tambahkan Swagger UI
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	// Menjalankan server di port 8081
-	log.Println("Server berjalan di http://localhost:8080")
-	if err := router.Run(":8080"); err != nil {
-		log.Fatalf("Gagal menjalankan server: %v", err)
+	// Variabel dari file .env jika ada; variabel environment yang sudah diset tidak ditimpa
+	envErr := godotenv.Load()
+
+	// Log terstruktur dalam format JSON, termasuk log bawaan gin
+	config.SetupLogger()
+	if envErr != nil && !errors.Is(envErr, fs.ErrNotExist) {
+		logging.Fatal("Gagal membaca file .env", "error", envErr)
+	}
+	gin.DebugPrintRouteFunc = func(method, path, handler string, _ int) {
+		slog.Debug("Route terdaftar", "method", method, "path", path, "handler", handler)
+	}
+	gin.DebugPrintFunc = func(format string, values ...interface{}) {
+		slog.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)))
 	}
+
+	// Tracing OpenTelemetry untuk request, query database dan pesan WebSocket
+	shutdownTracing := config.SetupTracing()
+
+	// Registrasi aturan validasi data
+	validation.Register()
+
+	// Aplikasi beserta database, storage cover, provider data buku berdasarkan ISBN dan
+	// tujuan peringatan untuk admin
+	registry := prometheus.NewRegistry()
+	a := setupApp(registry)
+
+	// Pekerjaan latar belakang berhenti saat server dimatikan
+	a.StartJobs()
+
+	// Menjalankan server di port 8080 sampai menerima SIGINT atau SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := &http.Server{Addr: ":8080", Handler: a.Router(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		slog.Info("Server berjalan", "alamat", "http://localhost:8080")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("Gagal menjalankan server", "error", err)
+		}
+	}()
+
+	// Metrics Prometheus di port internal yang terpisah dari API publik
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler(registry))
+	metricsServer := &http.Server{Addr: config.MetricsAddr(), Handler: metricsMux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		slog.Info("Metrics berjalan", "alamat", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("Gagal menjalankan server metrics", "error", err)
+		}
+	}()
+	<-ctx.Done()
+	stop()
+
+	slog.Info("Server berhenti, menunggu request dan koneksi selesai", "batas_waktu", config.ShutdownTimeout().String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout())
+	defer cancel()
+
+	// Readiness check gagal, klien WebSocket menerima close frame dan koneksi baru ditolak
+	if err := a.Drain(shutdownCtx); err != nil {
+		slog.Warn("Koneksi WebSocket tidak tertutup tepat waktu", "error", err)
+	}
+
+	// Berhenti menerima koneksi dan menunggu request yang sedang berjalan selesai
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Request tidak selesai tepat waktu", "error", err)
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Server metrics tidak berhenti tepat waktu", "error", err)
+	}
+
+	// Pekerjaan latar belakang menyelesaikan putaran yang sedang berjalan, lalu koneksi
+	// database ditutup
+	if err := a.Close(); err != nil {
+		slog.Warn("Gagal menutup koneksi database", "error", err)
+	}
+
+	// Sisa span dikirim sebelum proses berhenti
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("Gagal mengirim sisa span tracing", "error", err)
+	}
+	slog.Info("Server berhenti")
 }
 
-// setupDatabase mengatur koneksi ke database
-func setupDatabase() {
-	log.Println("Menghubungkan ke database...")
-	config.ConnectDatabase()
-	log.Println("Berhasil terhubung ke database!")
+// setupApp menghubungkan ke database dan layanan luar lalu membuat aplikasinya dengan metrics
+// yang didaftarkan ke reg
+func setupApp(reg prometheus.Registerer) *app.App {
+	slog.Info("Menghubungkan ke database")
+	database := config.ConnectDatabase()
+
+	a := app.New(app.ConfigFromEnv(), database, config.ConnectStorage(), config.ConnectMetadata(), config.ConnectNotifier(), reg)
+	if err := a.Migrate(); err != nil {
+		logging.Fatal("Gagal migrasi database", "error", err)
+	}
+	slog.Info("Database siap digunakan")
+	return a
 }
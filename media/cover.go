@@ -0,0 +1,57 @@
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+)
+
+// CoverKey membuat key untuk file cover asli komik. Nama file berasal dari hash isi file
+// sehingga setiap cover baru mendapat key (dan URL) yang berbeda
+func CoverKey(komikID uint, data []byte, ext string) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("covers/%d/%s%s", komikID, hex.EncodeToString(sum[:8]), ext)
+}
+
+// CoverHash mengembalikan hash isi cover dari key, dipakai sebagai ETag dan versi URL
+func CoverHash(key string) string {
+	return strings.TrimSuffix(path.Base(key), path.Ext(key))
+}
+
+// CoverURL membuat URL cover komik dari key cover, kosong jika komik belum memiliki cover.
+// URL memuat hash cover sehingga dapat di-cache selamanya oleh browser dan berubah setiap
+// kali cover diganti
+func CoverURL(komikID uint, key string) string {
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("/komik/%d/cover?v=%s", komikID, CoverHash(key))
+}
+
+// ThumbnailKey mengembalikan key thumbnail cover untuk ukuran tertentu
+func ThumbnailKey(key, ukuran string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + ukuran + ".jpg"
+}
+
+// CoverKeys mengembalikan key cover asli beserta semua thumbnail-nya
+func CoverKeys(key string) []string {
+	keys := []string{key}
+	for ukuran := range UkuranThumbnail {
+		keys = append(keys, ThumbnailKey(key, ukuran))
+	}
+	return keys
+}
+
+// DeleteCover menghapus cover beserta thumbnail-nya dari storage. Kegagalan hanya dicatat
+// karena data komik sudah tidak lagi menunjuk ke file tersebut
+func DeleteCover(storage Storage, key string) {
+	for _, fileKey := range CoverKeys(key) {
+		if err := storage.Delete(context.Background(), fileKey); err != nil {
+			slog.Error("Gagal menghapus file cover", "key", fileKey, "error", err)
+		}
+	}
+}
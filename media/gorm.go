@@ -0,0 +1,49 @@
+package media
+
+import (
+	"reflect"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// GormPlugin mengisi CoverURL pada setiap komik yang dibaca lewat GORM, termasuk komik
+// yang di-preload dari data lain. Dipasang dengan db.Use
+type GormPlugin struct{}
+
+// Name mengembalikan nama plugin
+func (GormPlugin) Name() string {
+	return "media"
+}
+
+// Initialize mendaftarkan callback setelah query
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	return db.Callback().Query().After("gorm:after_query").Register("media:cover_url", func(db *gorm.DB) {
+		if db.Error == nil {
+			setCoverURL(db.Statement.ReflectValue)
+		}
+	})
+}
+
+// setCoverURL mengisi CoverURL pada komik di value, yang dapat berupa komik, pointer ke
+// komik atau slice keduanya
+func setCoverURL(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			setCoverURL(value.Index(i))
+		}
+	case reflect.Pointer:
+		if !value.IsNil() {
+			setCoverURL(value.Elem())
+		}
+	case reflect.Struct:
+		if !value.CanAddr() {
+			return
+		}
+		if komik, ok := value.Addr().Interface().(*models.Komik); ok {
+			komik.CoverURL = CoverURL(komik.ID, komik.Cover)
+		}
+	}
+}
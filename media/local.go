@@ -0,0 +1,99 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage menyimpan file di direktori pada filesystem lokal
+type LocalStorage struct {
+	Dir string
+}
+
+// NewLocalStorage membuat LocalStorage dan memastikan direktorinya ada
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{Dir: dir}, nil
+}
+
+// Put menulis file ke file sementara lalu memindahkannya agar pembaca tidak melihat file setengah jadi
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get membuka file. Content type ditentukan dari ekstensi key
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, Object{}, ErrNotExist
+		}
+		return nil, Object{}, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, Object{}, err
+	}
+	return file, Object{
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// Delete menghapus file
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path mengubah key menjadi path di dalam Dir dan menolak key yang keluar dari Dir
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("key %q tidak valid", key)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,75 @@
+package media
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config berisi pengaturan koneksi ke storage yang kompatibel dengan S3 (AWS S3, MinIO, R2, dll)
+type S3Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+}
+
+// S3Storage menyimpan file di bucket S3
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Storage membuat S3Storage. Bucket harus sudah ada
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Storage{client: client, bucket: cfg.Bucket}, nil
+}
+
+// Put mengunggah file ke bucket
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// Get mengunduh file dari bucket
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, Object{}, s.translate(err)
+	}
+	// GetObject tidak menghubungi server sampai dibaca, Stat memastikan file ada
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, Object{}, s.translate(err)
+	}
+	return object, Object{
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ModTime:     info.LastModified,
+	}, nil
+}
+
+// Delete menghapus file dari bucket
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.translate(s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}))
+}
+
+func (s *S3Storage) translate(err error) error {
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotExist
+	}
+	return err
+}
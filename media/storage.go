@@ -0,0 +1,29 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotExist dikembalikan jika file yang diminta tidak ada di storage
+var ErrNotExist = errors.New("file tidak ditemukan di storage")
+
+// Object berisi informasi file yang disimpan di storage
+type Object struct {
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage adalah tempat penyimpanan file seperti cover komik. Key berupa path relatif
+// dengan pemisah "/", misalnya "covers/1/3fa9c2e1.png"
+type Storage interface {
+	// Put menyimpan isi r dengan panjang size byte ke key, menimpa file lama jika ada
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get membuka file pada key. Pemanggil wajib menutup reader yang dikembalikan
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	// Delete menghapus file pada key. Menghapus file yang tidak ada bukan error
+	Delete(ctx context.Context, key string) error
+}
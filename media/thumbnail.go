@@ -0,0 +1,68 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif" // decoder GIF
+	"image/jpeg"
+	_ "image/png" // decoder PNG
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // decoder WebP
+)
+
+// MaksimalPiksel membatasi resolusi gambar yang diproses agar gambar kecil dengan
+// resolusi sangat besar tidak menghabiskan memori saat di-decode
+const MaksimalPiksel = 40_000_000
+
+// UkuranThumbnail adalah lebar (piksel) thumbnail yang dibuat untuk setiap cover
+var UkuranThumbnail = map[string]int{
+	"kecil":  150,
+	"sedang": 300,
+	"besar":  600,
+}
+
+// ErrGambarTidakValid dikembalikan jika data bukan gambar yang dapat diproses
+var ErrGambarTidakValid = errors.New("gambar tidak valid atau resolusinya terlalu besar")
+
+// Thumbnails membuat thumbnail JPEG untuk setiap ukuran pada UkuranThumbnail.
+// Perbandingan sisi gambar dipertahankan dan gambar yang lebih kecil tidak diperbesar
+func Thumbnails(data []byte) (map[string][]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > MaksimalPiksel {
+		return nil, ErrGambarTidakValid
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrGambarTidakValid
+	}
+
+	thumbnails := make(map[string][]byte, len(UkuranThumbnail))
+	for ukuran, width := range UkuranThumbnail {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resize(src, width), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		thumbnails[ukuran] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// resize mengecilkan src menjadi selebar width piksel di atas latar putih
+// (JPEG tidak mendukung transparansi)
+func resize(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	if bounds.Dx() < width {
+		width = bounds.Dx()
+	}
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
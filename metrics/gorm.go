@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// startKey adalah kunci waktu mulai query yang disimpan di statement GORM
+const startKey = "metrics:start"
+
+// GormPlugin mencatat lama setiap query GORM ke DBQueryDuration milik Metrics di context
+// query (lihat NewContext). Query tanpa Metrics di context tidak dicatat. Dipasang dengan db.Use
+type GormPlugin struct{}
+
+// Name mengembalikan nama plugin
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize mendaftarkan callback sebelum dan sesudah setiap jenis operasi GORM
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("metrics:before_create", before),
+		cb.Create().After("*").Register("metrics:after_create", after("create")),
+		cb.Query().Before("*").Register("metrics:before_query", before),
+		cb.Query().After("*").Register("metrics:after_query", after("query")),
+		cb.Update().Before("*").Register("metrics:before_update", before),
+		cb.Update().After("*").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("*").Register("metrics:before_delete", before),
+		cb.Delete().After("*").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("*").Register("metrics:before_row", before),
+		cb.Row().After("*").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("*").Register("metrics:before_raw", before),
+		cb.Raw().After("*").Register("metrics:after_raw", after("raw")),
+	)
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func after(operasi string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		m := FromContext(db.Statement.Context)
+		if m == nil {
+			return
+		}
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		status := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			status = "error"
+		}
+		tabel := db.Statement.Table
+		if tabel == "" {
+			tabel = "lainnya"
+		}
+		m.DBQueryDuration.WithLabelValues(operasi, tabel, status).Observe(time.Since(start).Seconds())
+	}
+}
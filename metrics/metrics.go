@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace adalah awalan nama semua metric aplikasi
+const namespace = "komik"
+
+// Hasil login
+const (
+	LoginSukses = "sukses"
+	LoginGagal  = "gagal"
+)
+
+// Metrics berisi metric aplikasi milik satu App. Setiap App membuat Metrics sendiri yang
+// didaftarkan ke registry-nya, sehingga nilai metric beberapa App dalam satu proses tidak
+// tercampur
+type Metrics struct {
+	// HTTPRequests menghitung request per method, route template dan status
+	HTTPRequests *prometheus.CounterVec
+	// HTTPDuration mencatat lama pemrosesan request per method dan route template
+	HTTPDuration *prometheus.HistogramVec
+	// DBQueryDuration mencatat lama query database per operasi GORM dan tabel
+	DBQueryDuration *prometheus.HistogramVec
+	// WebsocketConnections adalah jumlah klien WebSocket yang sedang terhubung
+	WebsocketConnections prometheus.Gauge
+	// WebsocketDropped menghitung pesan yang tidak terkirim karena antrean klien penuh
+	WebsocketDropped prometheus.Counter
+	// Logins menghitung percobaan login per hasil (sukses atau gagal)
+	Logins *prometheus.CounterVec
+	// StockChanges menghitung catatan perubahan stok per alasan
+	StockChanges *prometheus.CounterVec
+	// DBReplicaUp bernilai 1 jika replika database dapat dihubungi pada pemeriksaan terakhir
+	DBReplicaUp *prometheus.GaugeVec
+}
+
+// New membuat Metrics lalu mendaftarkannya ke reg beserta metric runtime Go dan proses.
+// Metric runtime yang sudah terdaftar di reg tidak dianggap error
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Jumlah request HTTP per method, route dan status.",
+		}, []string{"method", "route", "status"}),
+		HTTPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Lama pemrosesan request HTTP dalam detik.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		DBQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Lama query database dalam detik per operasi dan tabel.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operasi", "tabel", "status"}),
+		WebsocketConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "websocket_connections",
+			Help:      "Jumlah klien WebSocket yang sedang terhubung.",
+		}),
+		WebsocketDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "websocket_dropped_messages_total",
+			Help:      "Jumlah pesan WebSocket yang dibuang karena antrean klien penuh.",
+		}),
+		Logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Jumlah percobaan login per hasil.",
+		}, []string{"hasil"}),
+		StockChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stock_changes_total",
+			Help:      "Jumlah catatan perubahan stok per alasan.",
+		}, []string{"alasan"}),
+		DBReplicaUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_replica_up",
+			Help:      "Status replika database pada pemeriksaan terakhir (1 tersedia, 0 tidak).",
+		}, []string{"replika"}),
+	}
+
+	var errs []error
+	for _, c := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	} {
+		var already prometheus.AlreadyRegisteredError
+		if err := reg.Register(c); err != nil && !errors.As(err, &already) {
+			errs = append(errs, err)
+		}
+	}
+	for _, c := range []prometheus.Collector{
+		m.HTTPRequests,
+		m.HTTPDuration,
+		m.DBQueryDuration,
+		m.WebsocketConnections,
+		m.WebsocketDropped,
+		m.Logins,
+		m.StockChanges,
+		m.DBReplicaUp,
+	} {
+		errs = append(errs, reg.Register(c))
+	}
+	return m, errors.Join(errs...)
+}
+
+// contextKey adalah kunci Metrics di dalam context
+type contextKey struct{}
+
+// NewContext menyimpan m di ctx agar query database dan pencatatan stok di dalam request
+// atau pekerjaan latar belakang dicatat ke metric App yang menjalankannya
+func NewContext(ctx context.Context, m *Metrics) context.Context {
+	return context.WithValue(ctx, contextKey{}, m)
+}
+
+// FromContext mengambil Metrics yang disimpan dengan NewContext, nil jika tidak ada
+func FromContext(ctx context.Context) *Metrics {
+	if ctx == nil {
+		return nil
+	}
+	m, _ := ctx.Value(contextKey{}).(*Metrics)
+	return m
+}
+
+// Handler mengembalikan handler HTTP untuk endpoint /metrics dalam format Prometheus yang
+// berisi semua metric di g
+func Handler(g prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(g, promhttp.HandlerOpts{})
+}
+
+// RegisterDBStats menambahkan statistik pool koneksi database ke reg, seperti koneksi terbuka,
+// koneksi yang sedang dipakai dan waktu menunggu koneksi, sebagai metric go_sql_* dengan
+// label db_name, misalnya "primary" atau "replica_1"
+func RegisterDBStats(reg prometheus.Registerer, db *sql.DB, nama string) error {
+	return reg.Register(collectors.NewDBStatsCollector(db, nama))
+}
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -8,8 +9,18 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// Auth membuat middleware yang memvalidasi token JWT dengan kunci milik satu App
+type Auth struct {
+	secretKey []byte
+}
+
+// NewAuth membuat Auth yang memvalidasi token yang ditandatangani dengan secretKey
+func NewAuth(secretKey []byte) *Auth {
+	return &Auth{secretKey: secretKey}
+}
+
 // AuthMiddleware memvalidasi token JWT dan memeriksa role user
-func AuthMiddleware(allowedRoles ...int) gin.HandlerFunc {
+func (a *Auth) AuthMiddleware(allowedRoles ...int) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -25,28 +36,14 @@ func AuthMiddleware(allowedRoles ...int) gin.HandlerFunc {
 			return
 		}
 
-		// Parse token JWT
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte("your_secret_key"), nil
-		})
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token tidak valid"})
-			c.Abort()
-			return
-		}
-
-		// Ambil klaim dari token
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok || !token.Valid {
+		userID, roleID, err := a.parseToken(tokenString)
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token tidak valid"})
 			c.Abort()
 			return
 		}
 
 		// Simpan role_id dan user_id ke dalam context
-		roleID := int(claims["role_id"].(float64))
-		userID := uint(claims["user_id"].(float64))
-
 		c.Set("user_id", userID)
 		c.Set("role_id", roleID)
 
@@ -68,3 +65,52 @@ func AuthMiddleware(allowedRoles ...int) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuth mengisi user_id dan role_id jika token dikirim, tetapi tetap meneruskan
+// request tanpa token. Token dapat dikirim lewat header Authorization atau query "token"
+// karena browser tidak dapat menambahkan header pada koneksi WebSocket
+func (a *Auth) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if tokenString == "" {
+			tokenString = c.Query("token")
+		}
+		if tokenString == "" {
+			c.Next()
+			return
+		}
+
+		userID, roleID, err := a.parseToken(tokenString)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token tidak valid"})
+			c.Abort()
+			return
+		}
+		c.Set("user_id", userID)
+		c.Set("role_id", roleID)
+		c.Next()
+	}
+}
+
+// parseToken memvalidasi token JWT dan mengambil user_id dan role_id dari klaimnya
+func (a *Auth) parseToken(tokenString string) (uint, int, error) {
+	// Parse token JWT
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return a.secretKey, nil
+	})
+	if err != nil || !token.Valid {
+		return 0, 0, errors.New("token tidak valid")
+	}
+
+	// Ambil klaim dari token
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, 0, errors.New("token tidak valid")
+	}
+	roleID, okRole := claims["role_id"].(float64)
+	userID, okUser := claims["user_id"].(float64)
+	if !okRole || !okUser {
+		return 0, 0, errors.New("token tidak valid")
+	}
+	return uint(userID), int(roleID), nil
+}
@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logger mencatat setiap request sebagai log terstruktur: method, route, status, durasi dan
+// user. Harus dipasang setelah RequestID agar log menyertakan ID request. Status 5xx dicatat
+// sebagai error dan 4xx sebagai warning
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("durasi_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("ip", c.ClientIP()),
+			slog.Int("ukuran", max(c.Writer.Size(), 0)),
+		}
+		if userID, ok := c.Get("user_id"); ok {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		slog.LogAttrs(c.Request.Context(), level, "Request selesai", attrs...)
+	}
+}
+
+// Recovery mengubah panic di handler menjadi response 500 dan mencatatnya beserta stack trace
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, err any) {
+		slog.ErrorContext(c.Request.Context(), "Panic saat memproses request",
+			"panic", fmt.Sprint(err),
+			"stack", string(debug.Stack()),
+		)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Terjadi kesalahan pada server"})
+	})
+}
@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"backend/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics mencatat jumlah dan lama request ke m per route template, misalnya /komik/:id, agar
+// jumlah label tidak bertambah untuk setiap ID. Request ke route yang tidak terdaftar
+// dikelompokkan sebagai "tidak_dikenal". m juga disimpan di context request agar query
+// database dan perubahan stok di dalam request tercatat ke m
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Request = c.Request.WithContext(metrics.NewContext(c.Request.Context(), m))
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "tidak_dikenal"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		m.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.HTTPDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
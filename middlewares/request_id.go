@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"backend/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderRequestID adalah header yang membawa ID request dari client dan dikembalikan di response
+const HeaderRequestID = "X-Request-ID"
+
+// ID request dari client hanya diterima jika terdiri dari huruf, angka, titik, garis bawah
+// dan tanda hubung dengan panjang maksimal 64 karakter, agar aman ditulis ke log dan header
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID memberi setiap request sebuah ID yang disimpan di context gin sebagai request_id
+// dan di context request untuk log. ID dari header X-Request-ID dipakai jika formatnya valid,
+// selain itu dibuat ID acak
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Header(HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Aksi yang dicatat di audit log
+const (
+	AuditCreate    = "create"
+	AuditUpdate    = "update"
+	AuditDelete    = "delete"
+	AuditImport    = "import"    // Impor katalog komik, perubahan berisi ringkasan per baris
+	AuditReconcile = "reconcile" // Rekonsiliasi catatan stok
+	AuditRedeliver = "redeliver" // Pengiriman ulang webhook
+)
+
+// ErrAuditLogAppendOnly dikembalikan jika audit log yang sudah tersimpan akan diubah atau dihapus
+var ErrAuditLogAppendOnly = errors.New("audit log tidak dapat diubah atau dihapus")
+
+// AuditLog mencatat siapa melakukan aksi admin apa pada data mana. Perubahan berisi field yang
+// berbeda dalam bentuk {"field": {"sebelum": ..., "sesudah": ...}}. Data hanya dapat ditambah
+type AuditLog struct {
+	ID        uint            `gorm:"primaryKey" json:"id"`
+	UserID    *uint           `gorm:"index" json:"user_id"` // Pelaku, diambil dari token
+	Aksi      string          `gorm:"size:20;not null;index" json:"aksi"`
+	Entitas   string          `gorm:"size:50;not null;index:idx_audit_entitas" json:"entitas"` // Misalnya komik, comment atau diskon
+	EntitasID uint            `gorm:"index:idx_audit_entitas" json:"entitas_id"`               // 0 jika aksi tidak untuk satu data
+	Perubahan json.RawMessage `gorm:"type:json" json:"perubahan" swaggertype:"object"`
+	IP        string          `gorm:"size:45" json:"ip"`
+	RequestID string          `gorm:"size:64;index" json:"request_id"`
+	CreatedAt time.Time       `gorm:"index" json:"created_at"`
+}
+
+// BeforeUpdate menolak perubahan audit log
+func (AuditLog) BeforeUpdate(*gorm.DB) error {
+	return ErrAuditLogAppendOnly
+}
+
+// BeforeDelete menolak penghapusan audit log
+func (AuditLog) BeforeDelete(*gorm.DB) error {
+	return ErrAuditLogAppendOnly
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Katalog berisi kolom yang dimiliki bersama oleh Author, Publisher dan Genre
+type Katalog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Nama      string    `gorm:"size:255" json:"nama"`
+	Kunci     string    `gorm:"size:255;uniqueIndex" json:"-"` // Nama yang dinormalisasi untuk mencegah duplikasi
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DataKatalog mengembalikan kolom katalog dari Author, Publisher atau Genre
+func (k *Katalog) DataKatalog() *Katalog {
+	return k
+}
+
+// EntitasKatalog diimplementasikan oleh *Author, *Publisher dan *Genre
+type EntitasKatalog interface {
+	DataKatalog() *Katalog
+}
+
+type Author struct {
+	Katalog
+}
+
+type Publisher struct {
+	Katalog
+}
+
+type Genre struct {
+	Katalog
+}
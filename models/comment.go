@@ -1,8 +1,27 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Comment struct {
-	ID       uint   `gorm:"primaryKey" json:"id"`
-	UserID   uint   `json:"user_id"`  // Relasi ke User
-	KomikID  uint   `json:"komik_id"` // Relasi ke Komik
-	Komentar string `json:"komentar"`
+	ID       uint       `gorm:"primaryKey" json:"id"`
+	UserID   uint       `json:"user_id"`                     // Relasi ke User
+	KomikID  uint       `json:"komik_id" binding:"required"` // Relasi ke Komik
+	Komentar string     `json:"komentar" binding:"notblank,max=2000"`
+	EditedAt *time.Time `json:"edited_at"` // Terisi jika komentar pernah diedit
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Version   uint      `gorm:"not null;default:1" json:"version"` // Bertambah setiap kali data diubah
+
+	// Komentar yang dihapus hanya ditandai agar riwayat editnya tetap dapat dilihat admin
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swaggerignore:"true"`
+
+	// Jumlah reaksi per jenis, diisi saat komentar ditampilkan
+	Reaksi      map[string]int64 `gorm:"-" json:"reaksi"`
+	TotalReaksi int64            `gorm:"-" json:"total_reaksi"`
+	ReaksiSaya  []string         `gorm:"-" json:"reaksi_saya"`
 }
@@ -0,0 +1,21 @@
+package models
+
+// JenisReaksi adalah daftar reaksi yang dapat diberikan pada komentar
+var JenisReaksi = []string{"like", "love", "haha", "wow", "sad", "angry"}
+
+// ReaksiValid memeriksa apakah reaksi termasuk dalam daftar JenisReaksi
+func ReaksiValid(reaksi string) bool {
+	for _, jenis := range JenisReaksi {
+		if jenis == reaksi {
+			return true
+		}
+	}
+	return false
+}
+
+type CommentReaction struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	CommentID uint   `gorm:"uniqueIndex:idx_comment_reaction" json:"comment_id"` // Relasi ke Comment
+	UserID    uint   `gorm:"uniqueIndex:idx_comment_reaction" json:"user_id"`    // Relasi ke User
+	Reaksi    string `gorm:"size:16;uniqueIndex:idx_comment_reaction" json:"reaksi"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// CommentRevision menyimpan isi komentar sebelum diedit
+type CommentRevision struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CommentID uint      `gorm:"index" json:"comment_id"` // Relasi ke Comment
+	EditorID  uint      `json:"editor_id"`               // User yang melakukan edit
+	Komentar  string    `gorm:"type:text" json:"komentar"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// DataMigration mencatat migrasi data yang sudah dijalankan, sehingga migrasi yang
+// mengubah data hanya dijalankan sekali meskipun server dijalankan ulang
+type DataMigration struct {
+	Nama      string    `gorm:"primaryKey;size:100" json:"nama"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Jenis diskon
+const (
+	DiskonPersen  = "persen"  // Potongan sebesar persentase dari harga
+	DiskonNominal = "nominal" // Potongan tetap dalam satuan terkecil mata uang
+)
+
+// Diskon adalah aturan potongan harga yang berlaku pada rentang waktu tertentu untuk satu
+// komik, semua komik dengan genre tertentu, atau semua komik dari publisher tertentu
+type Diskon struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Nama        string    `json:"nama" binding:"notblank,max=255"`
+	Jenis       string    `gorm:"size:10" json:"jenis" binding:"oneof=persen nominal"`
+	Nilai       int64     `json:"nilai" binding:"gt=0,nilai_diskon"`                   // Persen (1-100) atau potongan dalam satuan terkecil mata uang
+	MataUang    string    `gorm:"size:3" json:"mata_uang" binding:"omitempty,iso4217"` // Hanya untuk diskon nominal, berlaku pada komik dengan mata uang yang sama
+	KomikID     *uint     `gorm:"index" json:"komik_id" binding:"required_without_all=GenreID PublisherID,excluded_with=GenreID PublisherID"`
+	GenreID     *uint     `gorm:"index" json:"genre_id" binding:"excluded_with=KomikID PublisherID"`
+	PublisherID *uint     `gorm:"index" json:"publisher_id" binding:"excluded_with=KomikID GenreID"`
+	Mulai       time.Time `gorm:"index" json:"mulai" binding:"required"`
+	Berakhir    time.Time `gorm:"index" json:"berakhir" binding:"required,gtfield=Mulai"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
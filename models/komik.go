@@ -1,11 +1,41 @@
 package models
 
+import (
+	"time"
+
+	"backend/isbn"
+)
+
 type Komik struct {
-	ID          uint   `gorm:"primaryKey" json:"id"`
-	Nama        string `json:"nama"`
-	Author      string `json:"author"`
-	Genre       string `json:"genre"`
-	TahunTerbit int    `json:"tahun_terbit"`
-	Publisher   string `json:"publisher"`
-	Stok        int    `json:"stok"`
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Nama         string    `json:"nama" binding:"notblank,max=255"`
+	ISBN10       *string   `gorm:"size:10;uniqueIndex" json:"isbn10" binding:"omitempty,isbn10"`
+	ISBN13       *string   `gorm:"size:13;uniqueIndex" json:"isbn13" binding:"omitempty,isbn13"`
+	Author       string    `json:"author" binding:"max=255"` // Nama author, disalin dari tabel authors
+	Genre        string    `json:"genre" binding:"max=255"`  // Daftar genre dipisah koma, disalin dari tabel genres
+	TahunTerbit  int       `json:"tahun_terbit" binding:"tahun_terbit"`
+	Publisher    string    `json:"publisher" binding:"max=255"` // Nama publisher, disalin dari tabel publishers
+	Stok         int       `json:"stok" binding:"min=0"`
+	StokTersedia *int      `gorm:"-" json:"stok_tersedia,omitempty"`                                         // Stok dikurangi reservasi yang masih aktif
+	ReorderLevel int       `gorm:"not null;default:0" json:"reorder_level" binding:"min=0"`                  // Peringatan stok rendah dikirim jika stok di bawah nilai ini
+	Harga        int64     `json:"harga" binding:"min=0"`                                                    // Harga dalam satuan terkecil mata uang
+	MataUang     string    `gorm:"size:3;not null;default:IDR" json:"mata_uang" binding:"omitempty,iso4217"` // Kode mata uang ISO 4217
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Version      uint      `gorm:"not null;default:1" json:"version"` // Bertambah setiap kali data diubah
+
+	AuthorID    *uint   `gorm:"index" json:"author_id"`    // Relasi ke Author
+	PublisherID *uint   `gorm:"index" json:"publisher_id"` // Relasi ke Publisher
+	Genres      []Genre `gorm:"many2many:komik_genres" json:"genres"`
+	GenreIDs    []uint  `gorm:"-" json:"genre_ids,omitempty"` // Hanya dipakai saat input untuk memilih genre
+	Volume      *Volume `json:"volume,omitempty"`             // Series dan nomor volume komik ini
+
+	Cover    string `gorm:"size:255" json:"-"`            // Key file cover asli di storage
+	CoverURL string `gorm:"-" json:"cover_url,omitempty"` // URL cover diisi media.GormPlugin, tambahkan ?ukuran=kecil|sedang|besar untuk thumbnail
+}
+
+// NormalizeISBN menghapus tanda hubung pada ISBN dan melengkapi ISBN-10 atau ISBN-13
+// yang kosong dari pasangannya
+func (k *Komik) NormalizeISBN() {
+	k.ISBN10, k.ISBN13 = isbn.Complete(k.ISBN10, k.ISBN13)
 }
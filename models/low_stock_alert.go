@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// LowStockAlert menandai komik yang sudah diberi peringatan stok rendah dan stoknya masih
+// di bawah reorder level. Disimpan di database agar peringatan tidak dikirim ulang oleh
+// server lain atau setelah server dijalankan ulang
+type LowStockAlert struct {
+	KomikID   uint      `gorm:"primaryKey;autoIncrement:false" json:"komik_id"`
+	CreatedAt time.Time `json:"created_at"` // Waktu peringatan dikirim
+}
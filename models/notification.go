@@ -0,0 +1,25 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis notifikasi
+const (
+	NotifikasiBackInStock  = "back_in_stock" // Komik di wishlist tersedia kembali
+	NotifikasiCommentReply = "comment_reply" // User disebut dengan @username di komentar balasan
+	NotifikasiOrderStatus  = "order_status"  // Status pesanan (reservasi) berubah
+	NotifikasiModeration   = "moderation"    // Komentar user dihapus oleh admin
+)
+
+// Notification adalah notifikasi di kotak masuk user. Payload berisi data sesuai jenis
+// notifikasi, misalnya komik yang tersedia kembali
+type Notification struct {
+	ID        uint            `gorm:"primaryKey" json:"id"`
+	UserID    uint            `gorm:"not null;index:idx_notification_user_read" json:"user_id"`
+	Type      string          `gorm:"size:50;not null" json:"type"`
+	Payload   json.RawMessage `gorm:"type:json" json:"payload" swaggertype:"object"`
+	ReadAt    *time.Time      `gorm:"index:idx_notification_user_read" json:"read_at"` // nil jika belum dibaca
+	CreatedAt time.Time       `json:"created_at"`
+}
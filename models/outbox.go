@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tujuan event outbox
+const (
+	OutboxRealtime = "realtime" // Dikirim ke klien WebSocket
+	OutboxWebhook  = "webhook"  // Diteruskan ke antrean pengiriman webhook
+)
+
+// OutboxEvent adalah event yang disimpan dalam transaksi yang sama dengan perubahan datanya
+// lalu diteruskan oleh relay. Event hanya terkirim jika perubahannya tersimpan, dan tidak
+// hilang jika server berhenti sebelum event diteruskan
+type OutboxEvent struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	Channel     string          `gorm:"size:20;not null" json:"channel"`
+	Type        string          `gorm:"size:50;not null" json:"type"` // Jenis event WebSocket atau event webhook
+	UserID      *uint           `json:"user_id"`                      // Penerima event realtime, nil untuk semua klien
+	Payload     json.RawMessage `gorm:"type:json" json:"payload" swaggertype:"object"`
+	TraceParent string          `gorm:"size:55" json:"-"` // Trace context W3C saat event dibuat, untuk span relay
+	CreatedAt   time.Time       `json:"created_at"`
+	PublishedAt *time.Time      `gorm:"index" json:"published_at"` // nil jika belum diteruskan
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Status reservasi stok
+const (
+	ReservasiAktif        = "aktif"        // Masih menahan stok sampai Berakhir
+	ReservasiDikonfirmasi = "dikonfirmasi" // Pesanan dikonfirmasi dan stok sudah dikurangi
+	ReservasiDilepas      = "dilepas"      // Dibatalkan oleh user atau admin
+	ReservasiKedaluwarsa  = "kedaluwarsa"  // Tidak dikonfirmasi sampai Berakhir
+)
+
+// Reservation menahan sejumlah stok komik untuk seorang user selama checkout. Stok fisik
+// (Komik.Stok) baru berkurang saat reservasi dikonfirmasi, tetapi stok yang ditahan tidak
+// dapat dipesan atau dibeli oleh user lain
+type Reservation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	KomikID   uint      `gorm:"not null;index:idx_reservation_komik_status" json:"komik_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Jumlah    int       `gorm:"not null" json:"jumlah"`
+	Status    string    `gorm:"size:20;not null;index:idx_reservation_komik_status" json:"status"`
+	Berakhir  time.Time `gorm:"not null;index" json:"berakhir"` // Stok dilepas otomatis setelah waktu ini
+	Referensi string    `gorm:"size:255" json:"referensi"`      // Nomor pesanan saat dikonfirmasi
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Series adalah judul komik yang terdiri dari beberapa volume
+type Series struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Nama      string    `gorm:"size:255" json:"nama" binding:"notblank,max=255"`
+	Deskripsi string    `gorm:"type:text" json:"deskripsi" binding:"max=5000"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Volumes   []Volume  `json:"volumes,omitempty"`
+}
+
+// Volume menghubungkan komik dengan nomor volume pada sebuah series.
+// Stok setiap volume mengikuti stok komik yang terhubung
+type Volume struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	SeriesID  uint      `gorm:"uniqueIndex:idx_series_volume" json:"series_id"` // Relasi ke Series
+	Nomor     int       `gorm:"uniqueIndex:idx_series_volume" json:"nomor" binding:"min=1"`
+	KomikID   uint      `gorm:"uniqueIndex" json:"komik_id" binding:"required"` // Relasi ke Komik
+	Judul     string    `gorm:"size:255" json:"judul" binding:"max=255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Komik     *Komik    `json:"komik,omitempty"`
+	Chapters  []Chapter `json:"chapters,omitempty"`
+
+	// Tersedia bernilai true jika stok komik volume ini masih ada, diisi saat volume ditampilkan
+	Tersedia *bool `gorm:"-" json:"tersedia,omitempty"`
+}
+
+// Chapter adalah bab yang termasuk dalam sebuah volume
+type Chapter struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	VolumeID  uint      `gorm:"uniqueIndex:idx_volume_chapter" json:"volume_id"` // Relasi ke Volume
+	Nomor     int       `gorm:"uniqueIndex:idx_volume_chapter" json:"nomor" binding:"min=1"`
+	Judul     string    `gorm:"size:255" json:"judul" binding:"max=255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
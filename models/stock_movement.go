@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Alasan perubahan stok
+const (
+	AlasanRestock    = "restock"    // Barang masuk dari supplier
+	AlasanSale       = "sale"       // Terjual
+	AlasanAdjustment = "adjustment" // Koreksi manual, misalnya hasil stock opname
+	AlasanReturn     = "return"     // Dikembalikan oleh pembeli
+)
+
+// AlasanStokValid memeriksa apakah alasan perubahan stok dikenal
+func AlasanStokValid(alasan string) bool {
+	switch alasan {
+	case AlasanRestock, AlasanSale, AlasanAdjustment, AlasanReturn:
+		return true
+	}
+	return false
+}
+
+// StockMovement mencatat setiap perubahan stok komik. Jumlah Delta seluruh catatan
+// sebuah komik sama dengan stok komik tersebut
+type StockMovement struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	KomikID     uint      `gorm:"not null;index:idx_stock_komik_waktu" json:"komik_id"`
+	Delta       int       `gorm:"not null" json:"delta"` // Positif jika stok bertambah, negatif jika berkurang
+	Alasan      string    `gorm:"size:20;not null" json:"alasan"`
+	UserID      *uint     `gorm:"index" json:"user_id"`      // User yang melakukan perubahan, nil jika oleh sistem
+	Referensi   string    `gorm:"size:255" json:"referensi"` // Misalnya nomor pesanan atau nama file impor
+	StokSetelah int       `json:"stok_setelah"`              // Stok komik setelah perubahan ini
+	CreatedAt   time.Time `gorm:"index:idx_stock_komik_waktu" json:"created_at"`
+}
@@ -1,8 +1,13 @@
 package models
 
+import "time"
+
 type User struct {
-	ID       uint   `gorm:"primaryKey" json:"id"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	RoleID   uint   `json:"role_id"` // Role: 1 = Admin, 2 = User
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Username  string    `json:"username"`
+	Password  string    `json:"password"`
+	RoleID    uint      `json:"role_id"` // Role: 1 = Admin, 2 = User
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Version   uint      `gorm:"not null;default:1" json:"version"` // Bertambah setiap kali data diubah
 }
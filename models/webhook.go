@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis event webhook
+const (
+	EventKomikCreated      = "komik.created"
+	EventKomikUpdated      = "komik.updated"
+	EventKomikDeleted      = "komik.deleted"
+	EventKomikStockChanged = "komik.stock_changed" // Stok berubah lewat catatan stok, penjualan atau pesanan
+	EventCommentCreated    = "comment.created"
+	EventCommentUpdated    = "comment.updated"
+	EventCommentDeleted    = "comment.deleted"
+	EventOrderReserved     = "order.reserved" // Reservasi stok dibuat atau diperpanjang
+	EventOrderConfirmed    = "order.confirmed"
+	EventOrderReleased     = "order.released"
+	EventOrderExpired      = "order.expired"
+)
+
+// Status pengiriman webhook
+const (
+	PengirimanMenunggu = "menunggu" // Menunggu dikirim atau dicoba ulang pada BerikutnyaPada
+	PengirimanTerkirim = "terkirim" // Penerima merespons dengan status 2xx
+	PengirimanGagal    = "gagal"    // Semua percobaan gagal atau webhook sudah tidak aktif
+)
+
+// Webhook adalah langganan sistem lain terhadap event komik, komentar dan pesanan. Setiap
+// event dikirim sebagai HTTP POST ke URL dengan tanda tangan HMAC-SHA256 dari Secret
+type Webhook struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	URL       string    `gorm:"size:2048;not null" json:"url"`
+	Events    []string  `gorm:"serializer:json;type:json" json:"events"`
+	Secret    string    `gorm:"size:255;not null" json:"-"`
+	Aktif     bool      `gorm:"not null" json:"aktif"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribed memeriksa apakah webhook berlangganan event tertentu
+func (w Webhook) Subscribed(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery adalah satu pengiriman event ke webhook beserta hasil percobaan terakhirnya.
+// Payload disimpan persis seperti body yang dikirim sehingga tanda tangannya tetap sama
+// saat dicoba ulang atau dikirim ulang
+type WebhookDelivery struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	WebhookID      uint            `gorm:"not null;index" json:"webhook_id"`
+	Event          string          `gorm:"size:50;not null" json:"event"`
+	Payload        json.RawMessage `gorm:"type:json" json:"payload" swaggertype:"object"`
+	Status         string          `gorm:"size:20;not null;index:idx_webhook_delivery_due" json:"status"`
+	Percobaan      int             `gorm:"not null;default:0" json:"percobaan"`
+	BerikutnyaPada *time.Time      `gorm:"index:idx_webhook_delivery_due" json:"berikutnya_pada"` // nil jika sudah selesai
+	StatusCode     int             `json:"status_code"`                                           // Status HTTP dari percobaan terakhir, 0 jika tidak ada respons
+	Respons        string          `gorm:"type:text" json:"respons"`                              // Potongan body respons terakhir
+	Error          string          `gorm:"type:text" json:"error"`
+	TerkirimPada   *time.Time      `json:"terkirim_pada"`
+	RedeliveryOf   *uint           `json:"redelivery_of"` // Pengiriman asal jika dikirim ulang oleh admin
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Wishlist adalah komik yang ingin dibeli user. User diberi notifikasi saat komik yang
+// stoknya habis tersedia kembali
+type Wishlist struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_wishlist_user_komik" json:"user_id"`
+	KomikID   uint      `gorm:"not null;uniqueIndex:idx_wishlist_user_komik;index" json:"komik_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Komik *Komik `json:"komik,omitempty"`
+}
@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"backend/models"
+	"backend/outbox"
+	"backend/websocket"
+
+	"gorm.io/gorm"
+)
+
+// BackInStock adalah payload notifikasi saat komik di wishlist tersedia kembali
+type BackInStock struct {
+	KomikID uint   `json:"komik_id"`
+	Nama    string `json:"nama"`
+	Stok    int    `json:"stok"`
+}
+
+// CommentReply adalah payload notifikasi saat komentar user dibalas
+type CommentReply struct {
+	ReplyID  uint   `json:"reply_id"`
+	KomikID  uint   `json:"komik_id"`
+	UserID   uint   `json:"user_id"` // User yang membalas
+	Komentar string `json:"komentar"`
+}
+
+// OrderStatus adalah payload notifikasi saat status pesanan (reservasi stok) berubah
+type OrderStatus struct {
+	ReservationID uint   `json:"reservation_id"`
+	KomikID       uint   `json:"komik_id"`
+	Jumlah        int    `json:"jumlah"`
+	Status        string `json:"status"`
+	Referensi     string `json:"referensi"`
+}
+
+// Moderation adalah payload notifikasi saat komentar user dihapus oleh admin
+type Moderation struct {
+	CommentID uint   `json:"comment_id"`
+	KomikID   uint   `json:"komik_id"`
+	Aksi      string `json:"aksi"`   // Saat ini hanya "dihapus"
+	Alasan    string `json:"alasan"` // Alasan dari admin, boleh kosong
+}
+
+// Create menyimpan notifikasi yang sama untuk beberapa user beserta event WebSocket untuk
+// setiap pemiliknya. Panggil di dalam transaksi yang sama dengan perubahan datanya; event
+// diteruskan oleh relay outbox setelah transaksi selesai. User yang tidak terhubung tetap
+// dapat membacanya dari kotak masuk
+func Create(tx *gorm.DB, userIDs []uint, jenis string, payload interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	notifications := make([]models.Notification, len(userIDs))
+	for i, userID := range userIDs {
+		notifications[i] = models.Notification{UserID: userID, Type: jenis, Payload: data, CreatedAt: now}
+	}
+	if err := tx.Create(&notifications).Error; err != nil {
+		return err
+	}
+	for _, n := range notifications {
+		if err := outbox.SendToUser(tx, n.UserID, websocket.EventNotification, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyBackInStock memberi tahu semua user yang menyimpan komik di wishlist bahwa
+// komik tersebut tersedia kembali
+func NotifyBackInStock(tx *gorm.DB, komikID uint) error {
+	var komik models.Komik
+	if err := tx.Select("id", "nama", "stok").First(&komik, komikID).Error; err != nil {
+		return err
+	}
+
+	var userIDs []uint
+	if err := tx.Model(&models.Wishlist{}).Where("komik_id = ?", komikID).Order("user_id").Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+	return Create(tx, userIDs, models.NotifikasiBackInStock, BackInStock{KomikID: komik.ID, Nama: komik.Nama, Stok: komik.Stok})
+}
+
+// Pola @username di isi komentar. Tanda baca di akhir nama dibuang oleh mentions
+var mentionPattern = regexp.MustCompile(`@([^\s@]+)`)
+
+// mentions mengembalikan username yang disebut dengan @username di isi komentar
+func mentions(komentar string) []string {
+	var usernames []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(komentar, -1) {
+		if username := strings.TrimRight(match[1], ".,!?:;"); username != "" {
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
+
+// NotifyCommentReply memberi tahu user yang disebut dengan @username di komentar reply jika
+// user tersebut sudah berkomentar pada komik yang sama. Penulis komentar tidak diberi
+// notifikasi meskipun menyebut dirinya sendiri
+func NotifyCommentReply(tx *gorm.DB, reply *models.Comment) error {
+	usernames := mentions(reply.Komentar)
+	if len(usernames) == 0 {
+		return nil
+	}
+	var userIDs []uint
+	err := tx.Model(&models.User{}).
+		Where("username IN ? AND id <> ?", usernames, reply.UserID).
+		Where("id IN (?)", tx.Model(&models.Comment{}).Select("user_id").Where("komik_id = ? AND id <> ?", reply.KomikID, reply.ID)).
+		Order("id").Pluck("id", &userIDs).Error
+	if err != nil {
+		return err
+	}
+	return Create(tx, userIDs, models.NotifikasiCommentReply, CommentReply{
+		ReplyID:  reply.ID,
+		KomikID:  reply.KomikID,
+		UserID:   reply.UserID,
+		Komentar: reply.Komentar,
+	})
+}
+
+// NotifyOrderStatus memberi tahu pemilik setiap reservasi tentang status terbarunya
+func NotifyOrderStatus(tx *gorm.DB, reservations ...models.Reservation) error {
+	for _, r := range reservations {
+		err := Create(tx, []uint{r.UserID}, models.NotifikasiOrderStatus, OrderStatus{
+			ReservationID: r.ID,
+			KomikID:       r.KomikID,
+			Jumlah:        r.Jumlah,
+			Status:        r.Status,
+			Referensi:     r.Referensi,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyModeration memberi tahu pemilik komentar yang dihapus oleh admin
+func NotifyModeration(tx *gorm.DB, comment *models.Comment, alasan string) error {
+	return Create(tx, []uint{comment.UserID}, models.NotifikasiModeration, Moderation{
+		CommentID: comment.ID,
+		KomikID:   comment.KomikID,
+		Aksi:      "dihapus",
+		Alasan:    alasan,
+	})
+}
@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"backend/models"
+	"backend/tracing"
+
+	"gorm.io/gorm"
+)
+
+// orderEvents memetakan status reservasi ke event webhook pesanan
+var orderEvents = map[string]string{
+	models.ReservasiAktif:        models.EventOrderReserved,
+	models.ReservasiDikonfirmasi: models.EventOrderConfirmed,
+	models.ReservasiDilepas:      models.EventOrderReleased,
+	models.ReservasiKedaluwarsa:  models.EventOrderExpired,
+}
+
+// Broadcast menyimpan event WebSocket untuk semua klien yang terhubung. Semua fungsi di
+// paket ini harus dipanggil di dalam transaksi yang sama dengan perubahan datanya
+func Broadcast(tx *gorm.DB, jenis string, data interface{}) error {
+	return add(tx, models.OutboxRealtime, jenis, nil, data)
+}
+
+// SendToUser menyimpan event WebSocket untuk semua koneksi milik user tertentu
+func SendToUser(tx *gorm.DB, userID uint, jenis string, data interface{}) error {
+	return add(tx, models.OutboxRealtime, jenis, &userID, data)
+}
+
+// Webhook menyimpan event untuk webhook yang berlangganan, misalnya models.EventKomikUpdated
+func Webhook(tx *gorm.DB, event string, data interface{}) error {
+	return add(tx, models.OutboxWebhook, event, nil, data)
+}
+
+// Order menyimpan event webhook pesanan sesuai status terbaru setiap reservasi
+func Order(tx *gorm.DB, reservations ...models.Reservation) error {
+	for _, r := range reservations {
+		event, ok := orderEvents[r.Status]
+		if !ok {
+			return fmt.Errorf("status reservasi %q tidak memiliki event webhook", r.Status)
+		}
+		if err := Webhook(tx, event, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func add(tx *gorm.DB, channel, jenis string, userID *uint, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.OutboxEvent{
+		Channel:     channel,
+		Type:        jenis,
+		UserID:      userID,
+		Payload:     payload,
+		TraceParent: tracing.Inject(tx.Statement.Context),
+	}).Error
+}
@@ -0,0 +1,209 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// MataUangDefault dipakai jika komik atau diskon nominal tidak menyebutkan mata uang
+const MataUangDefault = "IDR"
+
+// MaksimalJumlah adalah jumlah terbanyak satu komik dalam satu penawaran
+const MaksimalJumlah = 10000
+
+var (
+	// ErrKomikTidakDitemukan dikembalikan jika komik pada item penawaran tidak ada
+	ErrKomikTidakDitemukan = errors.New("komik tidak ditemukan")
+	// ErrJumlahTerlaluBanyak dikembalikan jika jumlah satu komik melebihi MaksimalJumlah
+	ErrJumlahTerlaluBanyak = fmt.Errorf("jumlah satu komik maksimal %d", MaksimalJumlah)
+	// ErrHargaTerlaluBesar dikembalikan jika subtotal atau total tidak muat dalam int64
+	ErrHargaTerlaluBesar = errors.New("total harga terlalu besar untuk dihitung")
+)
+
+// Item adalah komik dan jumlah yang ingin dibeli
+type Item struct {
+	KomikID uint `json:"komik_id" binding:"required"`
+	Jumlah  int  `json:"jumlah" binding:"required,min=1,max=10000"`
+}
+
+// Baris adalah hasil perhitungan harga untuk satu komik
+type Baris struct {
+	KomikID     uint           `json:"komik_id"`
+	Nama        string         `json:"nama"`
+	MataUang    string         `json:"mata_uang"`
+	HargaSatuan int64          `json:"harga_satuan"` // Harga sebelum diskon
+	Jumlah      int            `json:"jumlah"`
+	Subtotal    int64          `json:"subtotal"`         // HargaSatuan x Jumlah
+	Diskon      *models.Diskon `json:"diskon,omitempty"` // Diskon yang dipakai
+	Potongan    int64          `json:"potongan"`         // Total potongan untuk baris ini
+	Total       int64          `json:"total"`            // Subtotal - Potongan
+	Tersedia    bool           `json:"tersedia"`         // Stok mencukupi untuk jumlah yang diminta
+}
+
+// Penawaran adalah hasil perhitungan harga untuk seluruh item
+type Penawaran struct {
+	Items []Baris          `json:"items"`
+	Total map[string]int64 `json:"total"` // Total per mata uang
+	Waktu time.Time        `json:"waktu"` // Diskon dipilih berdasarkan waktu ini
+}
+
+// Quote menghitung harga item pada waktu at. Item dengan komik yang sama digabung dan
+// jumlahnya tidak boleh melebihi MaksimalJumlah. Setiap baris memakai satu diskon yang
+// memberi potongan terbesar, diskon tidak digabung
+func Quote(tx *gorm.DB, items []Item, at time.Time) (*Penawaran, error) {
+	jumlah := make(map[uint]int)
+	var ids []uint
+	for _, item := range items {
+		if _, ok := jumlah[item.KomikID]; !ok {
+			ids = append(ids, item.KomikID)
+		}
+		if item.Jumlah > MaksimalJumlah-jumlah[item.KomikID] {
+			return nil, fmt.Errorf("%w (komik %d)", ErrJumlahTerlaluBanyak, item.KomikID)
+		}
+		jumlah[item.KomikID] += item.Jumlah
+	}
+
+	var komik []models.Komik
+	if err := tx.Preload("Genres").Where("id IN ?", ids).Find(&komik).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Komik, len(komik))
+	for _, k := range komik {
+		byID[k.ID] = k
+	}
+
+	diskon, err := ActiveDiscounts(tx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	penawaran := &Penawaran{Items: []Baris{}, Total: map[string]int64{}, Waktu: at}
+	for _, id := range ids {
+		k, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrKomikTidakDitemukan, id)
+		}
+		baris, err := Line(k, jumlah[id], diskon)
+		if err != nil {
+			return nil, err
+		}
+		total, err := tambah(penawaran.Total[baris.MataUang], baris.Total)
+		if err != nil {
+			return nil, err
+		}
+		penawaran.Items = append(penawaran.Items, baris)
+		penawaran.Total[baris.MataUang] = total
+	}
+	return penawaran, nil
+}
+
+// ActiveDiscounts mengambil semua diskon yang berlaku pada waktu at
+func ActiveDiscounts(tx *gorm.DB, at time.Time) ([]models.Diskon, error) {
+	var diskon []models.Diskon
+	err := tx.Where("mulai <= ? AND berakhir > ?", at, at).Order("id").Find(&diskon).Error
+	return diskon, err
+}
+
+// Line menghitung harga jumlah buah komik dengan diskon terbaik dari daftar diskon.
+// Genre komik harus sudah di-preload agar diskon per genre dapat diterapkan.
+// ErrHargaTerlaluBesar dikembalikan jika subtotal tidak muat dalam int64
+func Line(komik models.Komik, jumlah int, diskon []models.Diskon) (Baris, error) {
+	subtotal, err := kali(komik.Harga, int64(jumlah))
+	if err != nil {
+		return Baris{}, err
+	}
+	baris := Baris{
+		KomikID:     komik.ID,
+		Nama:        komik.Nama,
+		MataUang:    MataUang(komik.MataUang),
+		HargaSatuan: komik.Harga,
+		Jumlah:      jumlah,
+		Subtotal:    subtotal,
+		Tersedia:    komik.Stok >= jumlah,
+	}
+
+	var perBuah int64
+	for i := range diskon {
+		if !berlaku(diskon[i], komik) {
+			continue
+		}
+		if potongan := Potongan(komik, diskon[i]); potongan > perBuah {
+			perBuah = potongan
+			baris.Diskon = &diskon[i]
+		}
+	}
+	// Potongan dihitung per buah lalu dikalikan agar total baris selalu kelipatan harga per
+	// buah. Potongan per buah tidak melebihi harga, jadi hasilnya tidak melebihi subtotal
+	baris.Potongan = perBuah * int64(jumlah)
+	baris.Total = baris.Subtotal - baris.Potongan
+	return baris, nil
+}
+
+// Potongan menghitung potongan harga satu buah komik dari sebuah diskon. Potongan persen
+// dibulatkan ke satuan terdekat dan tidak pernah melebihi harga komik
+func Potongan(komik models.Komik, diskon models.Diskon) int64 {
+	var potongan int64
+	switch diskon.Jenis {
+	case models.DiskonPersen:
+		// Dihitung per ratusan agar harga yang besar tidak meluap saat dikalikan persen
+		potongan = komik.Harga/100*diskon.Nilai + (komik.Harga%100*diskon.Nilai+50)/100
+	case models.DiskonNominal:
+		if MataUang(diskon.MataUang) != MataUang(komik.MataUang) {
+			return 0
+		}
+		potongan = diskon.Nilai
+	}
+	if potongan > komik.Harga {
+		potongan = komik.Harga
+	}
+	return potongan
+}
+
+// berlaku memeriksa apakah diskon ditujukan untuk komik, genre atau publisher komik
+func berlaku(diskon models.Diskon, komik models.Komik) bool {
+	switch {
+	case diskon.KomikID != nil:
+		return *diskon.KomikID == komik.ID
+	case diskon.PublisherID != nil:
+		return komik.PublisherID != nil && *diskon.PublisherID == *komik.PublisherID
+	case diskon.GenreID != nil:
+		for _, genre := range komik.Genres {
+			if genre.ID == *diskon.GenreID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MataUang mengembalikan kode mata uang, atau MataUangDefault jika kode kosong
+func MataUang(kode string) string {
+	if kode == "" {
+		return MataUangDefault
+	}
+	return kode
+}
+
+// kali mengalikan a dan b yang tidak negatif, ErrHargaTerlaluBesar jika hasilnya tidak muat
+// dalam int64
+func kali(a, b int64) (int64, error) {
+	if a != 0 && b > math.MaxInt64/a {
+		return 0, ErrHargaTerlaluBesar
+	}
+	return a * b, nil
+}
+
+// tambah menjumlahkan a dan b yang tidak negatif, ErrHargaTerlaluBesar jika hasilnya tidak
+// muat dalam int64
+func tambah(a, b int64) (int64, error) {
+	if b > math.MaxInt64-a {
+		return 0, ErrHargaTerlaluBesar
+	}
+	return a + b, nil
+}
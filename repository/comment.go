@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+
+	"backend/models"
+	"backend/notification"
+	"backend/outbox"
+
+	"gorm.io/gorm"
+)
+
+// Urutan komentar untuk CommentFilter.Urutan
+const (
+	UrutanTerbaru = "terbaru" // ID terbesar lebih dulu
+	UrutanTop     = "top"     // Jumlah reaksi terbanyak lebih dulu
+)
+
+// CommentFilter membatasi komentar yang diambil List. Field kosong tidak dipakai sebagai filter
+type CommentFilter struct {
+	UserID  uint   // Hanya komentar milik user ini
+	KomikID uint   // Hanya komentar pada komik ini
+	Urutan  string // UrutanTerbaru atau UrutanTop, kosong untuk urutan bawaan database
+}
+
+// CommentDeletion adalah keterangan penghapusan komentar
+type CommentDeletion struct {
+	Actor  Actor
+	Audit  bool // Penghapusan dicatat di audit log
+	Notify bool // Pemilik komentar diberi notifikasi moderasi beserta Alasan
+	Alasan string
+}
+
+// CommentRepository menyimpan dan mengambil komentar beserta revisi dan reaksinya
+type CommentRepository interface {
+	// List mengambil komentar sesuai filter
+	List(ctx context.Context, filter CommentFilter) ([]models.Comment, error)
+	// Get mengambil satu komentar untuk ditampilkan
+	Get(ctx context.Context, id uint) (models.Comment, error)
+	// Find mengambil komentar dari database utama untuk diubah atau dihapus
+	Find(ctx context.Context, id uint) (models.Comment, error)
+	// GetWithDeleted mengambil satu komentar termasuk yang sudah dihapus
+	GetWithDeleted(ctx context.Context, id uint) (models.Comment, error)
+	// History mengambil isi komentar sebelum diedit, dari yang paling lama
+	History(ctx context.Context, id uint) ([]models.CommentRevision, error)
+	// AttachReactions mengisi jumlah reaksi dan reaksi milik userID pada setiap komentar
+	AttachReactions(ctx context.Context, comments []models.Comment, userID uint) error
+	// Create menyimpan komentar baru. User yang disebut dengan @username dan sudah berkomentar
+	// pada komik yang sama diberi notifikasi balasan
+	Create(ctx context.Context, comment *models.Comment) error
+	// Update menyimpan perubahan komentar dengan pengecekan versi. Isi sebelumnya disimpan
+	// sebagai revisi jika berbeda
+	Update(ctx context.Context, comment *models.Comment, previous string, editorID uint) error
+	// Delete menandai komentar sebagai dihapus dan menghapus reaksinya dengan pengecekan versi.
+	// Revisi komentar tetap disimpan untuk riwayat
+	Delete(ctx context.Context, comment *models.Comment, deletion CommentDeletion) error
+	// ToggleReaction menambahkan reaksi userID pada komentar atau membatalkannya jika sudah
+	// ada, lalu mengisi jumlah reaksi terbaru komentar tersebut
+	ToggleReaction(ctx context.Context, comment *models.Comment, userID uint, reaksi string) error
+}
+
+// commentRepository menyimpan komentar di database lewat GORM
+type commentRepository struct {
+	db     *gorm.DB
+	readDB *gorm.DB
+}
+
+// NewCommentRepository membuat CommentRepository dari koneksi database utama dan koneksi
+// untuk query baca yang dapat diarahkan ke replika
+func NewCommentRepository(db, readDB *gorm.DB) CommentRepository {
+	return &commentRepository{db: db, readDB: readDB}
+}
+
+func (r *commentRepository) List(ctx context.Context, filter CommentFilter) ([]models.Comment, error) {
+	query := r.readDB.WithContext(ctx)
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.KomikID != 0 {
+		query = query.Where("komik_id = ?", filter.KomikID)
+	}
+	switch filter.Urutan {
+	case UrutanTop:
+		query = query.Order("(SELECT COUNT(*) FROM comment_reactions WHERE comment_reactions.comment_id = comments.id) DESC").Order("id DESC")
+	case UrutanTerbaru:
+		query = query.Order("id DESC")
+	}
+
+	var comments []models.Comment
+	if err := query.Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) Get(ctx context.Context, id uint) (models.Comment, error) {
+	var comment models.Comment
+	err := r.readDB.WithContext(ctx).First(&comment, id).Error
+	return comment, notFound(err)
+}
+
+func (r *commentRepository) Find(ctx context.Context, id uint) (models.Comment, error) {
+	var comment models.Comment
+	err := r.db.WithContext(ctx).First(&comment, id).Error
+	return comment, notFound(err)
+}
+
+func (r *commentRepository) GetWithDeleted(ctx context.Context, id uint) (models.Comment, error) {
+	var comment models.Comment
+	err := r.readDB.WithContext(ctx).Unscoped().First(&comment, id).Error
+	return comment, notFound(err)
+}
+
+func (r *commentRepository) History(ctx context.Context, id uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	if err := r.readDB.WithContext(ctx).Where("comment_id = ?", id).Order("id ASC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *commentRepository) AttachReactions(ctx context.Context, comments []models.Comment, userID uint) error {
+	return attachReactions(r.readDB.WithContext(ctx), comments, userID)
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		if err := outbox.Webhook(tx, models.EventCommentCreated, comment); err != nil {
+			return err
+		}
+		return notification.NotifyCommentReply(tx, comment)
+	})
+}
+
+func (r *commentRepository) Update(ctx context.Context, comment *models.Comment, previous string, editorID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Simpan isi komentar sebelumnya sebagai revisi
+		if comment.Komentar != previous {
+			revision := models.CommentRevision{
+				CommentID: comment.ID,
+				EditorID:  editorID,
+				Komentar:  previous,
+			}
+			if err := tx.Create(&revision).Error; err != nil {
+				return err
+			}
+			editedAt := revision.CreatedAt
+			comment.EditedAt = &editedAt
+		}
+		if err := UpdateVersioned(tx, comment, &comment.Version); err != nil {
+			return err
+		}
+		return outbox.Webhook(tx, models.EventCommentUpdated, comment)
+	})
+}
+
+func (r *commentRepository) Delete(ctx context.Context, comment *models.Comment, deletion CommentDeletion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&models.CommentReaction{}).Error; err != nil {
+			return err
+		}
+		if err := DeleteVersioned(tx, comment, comment.Version); err != nil {
+			return err
+		}
+		if err := outbox.Webhook(tx, models.EventCommentDeleted, comment); err != nil {
+			return err
+		}
+		if deletion.Audit {
+			if err := deletion.Actor.Audit(tx, models.AuditDelete, "comment", comment.ID, comment, nil); err != nil {
+				return err
+			}
+		}
+		if !deletion.Notify {
+			return nil
+		}
+		return notification.NotifyModeration(tx, comment, deletion.Alasan)
+	})
+}
+
+func (r *commentRepository) ToggleReaction(ctx context.Context, comment *models.Comment, userID uint, reaksi string) error {
+	db := r.db.WithContext(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing models.CommentReaction
+		result := tx.Where("comment_id = ? AND user_id = ? AND reaksi = ?", comment.ID, userID, reaksi).Limit(1).Find(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// Reaksi yang sudah ada dibatalkan, selain itu ditambahkan
+		if result.RowsAffected > 0 {
+			return tx.Delete(&existing).Error
+		}
+		return tx.Create(&models.CommentReaction{
+			CommentID: comment.ID,
+			UserID:    userID,
+			Reaksi:    reaksi,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	comments := []models.Comment{*comment}
+	if err := attachReactions(db, comments, userID); err != nil {
+		return err
+	}
+	*comment = comments[0]
+	return nil
+}
+
+// attachReactions mengisi jumlah reaksi dan reaksi milik user pada setiap komentar
+func attachReactions(db *gorm.DB, comments []models.Comment, userID uint) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+		comments[i].Reaksi = make(map[string]int64)
+		comments[i].TotalReaksi = 0
+		comments[i].ReaksiSaya = []string{}
+	}
+
+	var counts []struct {
+		CommentID uint
+		Reaksi    string
+		Jumlah    int64
+	}
+	err := db.Model(&models.CommentReaction{}).
+		Select("comment_id, reaksi, COUNT(*) AS jumlah").
+		Where("comment_id IN ?", ids).
+		Group("comment_id, reaksi").
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	var mine []models.CommentReaction
+	if err := db.Where("comment_id IN ? AND user_id = ?", ids, userID).Find(&mine).Error; err != nil {
+		return err
+	}
+
+	index := make(map[uint]*models.Comment, len(comments))
+	for i := range comments {
+		index[comments[i].ID] = &comments[i]
+	}
+	for _, count := range counts {
+		if comment, ok := index[count.CommentID]; ok {
+			comment.Reaksi[count.Reaksi] = count.Jumlah
+			comment.TotalReaksi += count.Jumlah
+		}
+	}
+	for _, reaction := range mine {
+		if comment, ok := index[reaction.CommentID]; ok {
+			comment.ReaksiSaya = append(comment.ReaksiSaya, reaction.Reaksi)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/catalog"
+	"backend/inventory"
+	"backend/models"
+	"backend/notification"
+	"backend/outbox"
+
+	"gorm.io/gorm"
+)
+
+// KomikFilter membatasi komik yang diambil List. Field kosong tidak dipakai sebagai filter
+type KomikFilter struct {
+	AuthorID    string
+	PublisherID string
+	GenreID     string
+}
+
+// KomikRepository menyimpan dan mengambil data komik. Setiap perubahan disimpan dalam satu
+// transaksi bersama catatan stok, audit log dan event outbox-nya
+type KomikRepository interface {
+	// List mengambil komik sesuai filter beserta genre, volume dan stok tersedianya
+	List(ctx context.Context, filter KomikFilter) ([]models.Komik, error)
+	// Get mengambil satu komik beserta genre, volume dan stok tersedianya untuk ditampilkan
+	Get(ctx context.Context, id uint) (models.Komik, error)
+	// Find mengambil data komik tanpa relasinya dari database utama untuk diubah atau dihapus
+	Find(ctx context.Context, id uint) (models.Komik, error)
+	// FindByISBN mengambil komik yang memakai salah satu ISBN tersebut. ISBN yang nil tidak
+	// dicari
+	FindByISBN(ctx context.Context, isbn10, isbn13 *string) (models.Komik, error)
+	// Create menyimpan komik baru beserta genre dan stok awalnya
+	Create(ctx context.Context, komik *models.Komik, actor Actor) error
+	// Update menyimpan perubahan komik dengan pengecekan versi. previous adalah data komik
+	// sebelum diubah, nil jika seluruh data diganti
+	Update(ctx context.Context, komik *models.Komik, previous *models.Komik, actor Actor) error
+	// Delete menghapus komik beserta volume dan relasi genrenya dengan pengecekan versi
+	Delete(ctx context.Context, komik *models.Komik, actor Actor) error
+}
+
+// komikRepository menyimpan komik di database lewat GORM
+type komikRepository struct {
+	db     *gorm.DB
+	readDB *gorm.DB
+}
+
+// NewKomikRepository membuat KomikRepository dari koneksi database utama dan koneksi untuk
+// query baca yang dapat diarahkan ke replika
+func NewKomikRepository(db, readDB *gorm.DB) KomikRepository {
+	return &komikRepository{db: db, readDB: readDB}
+}
+
+func (r *komikRepository) List(ctx context.Context, filter KomikFilter) ([]models.Komik, error) {
+	db := r.readDB.WithContext(ctx)
+	query := db.Preload("Genres", OrderGenres).Preload("Volume")
+	if filter.AuthorID != "" {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+	if filter.PublisherID != "" {
+		query = query.Where("publisher_id = ?", filter.PublisherID)
+	}
+	if filter.GenreID != "" {
+		query = query.Where("id IN (?)", db.Table("komik_genres").Select("komik_id").Where("genre_id = ?", filter.GenreID))
+	}
+
+	var komik []models.Komik
+	if err := query.Find(&komik).Error; err != nil {
+		return nil, err
+	}
+	if err := setStokTersedia(db, komik); err != nil {
+		return nil, err
+	}
+	return komik, nil
+}
+
+func (r *komikRepository) Get(ctx context.Context, id uint) (models.Komik, error) {
+	db := r.readDB.WithContext(ctx)
+	var komik models.Komik
+	if err := db.Preload("Genres", OrderGenres).Preload("Volume").First(&komik, id).Error; err != nil {
+		return komik, notFound(err)
+	}
+	komiks := []models.Komik{komik}
+	if err := setStokTersedia(db, komiks); err != nil {
+		return komik, err
+	}
+	return komiks[0], nil
+}
+
+func (r *komikRepository) Find(ctx context.Context, id uint) (models.Komik, error) {
+	var komik models.Komik
+	err := r.db.WithContext(ctx).First(&komik, id).Error
+	return komik, notFound(err)
+}
+
+func (r *komikRepository) FindByISBN(ctx context.Context, isbn10, isbn13 *string) (models.Komik, error) {
+	var komik models.Komik
+	err := r.readDB.WithContext(ctx).Where("isbn10 = ?", isbn10).Or("isbn13 = ?", isbn13).First(&komik).Error
+	return komik, notFound(err)
+}
+
+func (r *komikRepository) Create(ctx context.Context, komik *models.Komik, actor Actor) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := catalog.CheckISBN(tx, komik); err != nil {
+			return err
+		}
+		if err := catalog.Resolve(tx, komik, nil); err != nil {
+			return err
+		}
+		if err := tx.Omit("Genres").Create(komik).Error; err != nil {
+			return err
+		}
+		if err := catalog.SaveGenres(tx, komik); err != nil {
+			return err
+		}
+		err := inventory.Log(tx, &models.StockMovement{
+			KomikID:     komik.ID,
+			Delta:       komik.Stok,
+			Alasan:      models.AlasanRestock,
+			UserID:      actor.userID(),
+			Referensi:   "stok awal",
+			StokSetelah: komik.Stok,
+		})
+		if err != nil {
+			return err
+		}
+		if err := actor.Audit(tx, models.AuditCreate, "komik", komik.ID, nil, auditKomik(*komik)); err != nil {
+			return err
+		}
+		return outbox.Webhook(tx, models.EventKomikCreated, komik)
+	})
+}
+
+func (r *komikRepository) Update(ctx context.Context, komik *models.Komik, previous *models.Komik, actor Actor) error {
+	movement := models.StockMovement{KomikID: komik.ID, Alasan: models.AlasanAdjustment, UserID: actor.userID()}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := catalog.CheckISBN(tx, komik); err != nil {
+			return err
+		}
+		if err := catalog.Resolve(tx, komik, previous); err != nil {
+			return err
+		}
+		var before models.Komik
+		if err := tx.First(&before, komik.ID).Error; err != nil {
+			return err
+		}
+		if err := UpdateVersioned(tx, komik, &komik.Version); err != nil {
+			return err
+		}
+		if err := catalog.SaveGenres(tx, komik); err != nil {
+			return err
+		}
+		// Stok yang diganti langsung dicatat sebagai koreksi
+		movement.Delta = komik.Stok - before.Stok
+		movement.StokSetelah = komik.Stok
+		if err := inventory.Log(tx, &movement); err != nil {
+			return err
+		}
+		if inventory.BackInStock(&movement) {
+			if err := notification.NotifyBackInStock(tx, movement.KomikID); err != nil {
+				return err
+			}
+		}
+		if err := actor.Audit(tx, models.AuditUpdate, "komik", komik.ID, auditKomik(before), auditKomik(*komik)); err != nil {
+			return err
+		}
+		return outbox.Webhook(tx, models.EventKomikUpdated, komik)
+	})
+}
+
+func (r *komikRepository) Delete(ctx context.Context, komik *models.Komik, actor Actor) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(komik).Association("Genres").Clear(); err != nil {
+			return err
+		}
+		if err := DeleteVolumes(tx, "komik_id = ?", komik.ID); err != nil {
+			return err
+		}
+		if err := DeleteVersioned(tx, komik, komik.Version); err != nil {
+			return err
+		}
+		if err := actor.Audit(tx, models.AuditDelete, "komik", komik.ID, auditKomik(*komik), nil); err != nil {
+			return err
+		}
+		return outbox.Webhook(tx, models.EventKomikDeleted, komik)
+	})
+}
+
+// auditKomik mengosongkan relasi dan field turunan komik agar audit log hanya berisi kolom
+// yang tersimpan. Genre tetap tercatat lewat field genre
+func auditKomik(komik models.Komik) models.Komik {
+	komik.Genres = nil
+	komik.GenreIDs = nil
+	komik.Volume = nil
+	komik.StokTersedia = nil
+	komik.CoverURL = ""
+	return komik
+}
+
+// setStokTersedia mengisi stok yang masih dapat dipesan, yaitu stok dikurangi reservasi aktif
+func setStokTersedia(db *gorm.DB, komiks []models.Komik) error {
+	if len(komiks) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(komiks))
+	for i, komik := range komiks {
+		ids[i] = komik.ID
+	}
+	reserved, err := inventory.Reserved(db, ids, 0, time.Now())
+	if err != nil {
+		return err
+	}
+	for i := range komiks {
+		tersedia := komiks[i].Stok - reserved[komiks[i].ID]
+		komiks[i].StokTersedia = &tersedia
+	}
+	return nil
+}
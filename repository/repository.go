@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+
+	"backend/audit"
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound dikembalikan jika data yang dicari tidak ada
+	ErrNotFound = errors.New("data tidak ditemukan")
+	// ErrVersionConflict menandakan data sudah diubah oleh request lain
+	ErrVersionConflict = errors.New("versi data tidak sesuai")
+)
+
+// Actor adalah user yang melakukan aksi beserta asal request-nya. Dicatat di audit log dan
+// catatan stok dari perubahan yang dilakukannya
+type Actor struct {
+	UserID    uint // 0 jika request tidak memakai token
+	RoleID    int  // 1 untuk admin, 2 untuk user
+	IP        string
+	RequestID string
+}
+
+// IsAdmin melaporkan apakah actor adalah admin
+func (a Actor) IsAdmin() bool {
+	return a.RoleID == 1
+}
+
+// Audit mencatat aksi actor beserta perubahan datanya. Dipanggil di dalam transaksi aksinya
+func (a Actor) Audit(tx *gorm.DB, aksi, entitas string, entitasID uint, before, after interface{}) error {
+	return audit.Log(tx, audit.Entry{
+		UserID:    a.userID(),
+		Aksi:      aksi,
+		Entitas:   entitas,
+		EntitasID: entitasID,
+		IP:        a.IP,
+		RequestID: a.RequestID,
+	}, before, after)
+}
+
+// userID mengembalikan ID user actor, nil jika request tidak memakai token
+func (a Actor) userID() *uint {
+	if a.UserID == 0 {
+		return nil
+	}
+	id := a.UserID
+	return &id
+}
+
+// UpdateVersioned menyimpan seluruh kolom value hanya jika versi di database masih sama,
+// lalu menaikkan versinya. ErrVersionConflict dikembalikan jika versi sudah berubah
+func UpdateVersioned(tx *gorm.DB, value interface{}, version *uint) error {
+	current := *version
+	*version = current + 1
+
+	result := tx.Model(value).Where("version = ?", current).Select("*").Updates(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = current
+		return result.Error
+	}
+	return nil
+}
+
+// DeleteVersioned menghapus value hanya jika versi di database masih sama
+func DeleteVersioned(tx *gorm.DB, value interface{}, version uint) error {
+	result := tx.Where("version = ?", version).Delete(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return result.Error
+}
+
+// DeleteVolumes menghapus volume yang memenuhi kondisi beserta chapter-nya
+func DeleteVolumes(tx *gorm.DB, query string, args ...interface{}) error {
+	volumeIDs := tx.Model(&models.Volume{}).Select("id").Where(query, args...)
+	if err := tx.Where("volume_id IN (?)", volumeIDs).Delete(&models.Chapter{}).Error; err != nil {
+		return err
+	}
+	return tx.Where(query, args...).Delete(&models.Volume{}).Error
+}
+
+// OrderGenres mengurutkan genre yang di-preload berdasarkan nama
+func OrderGenres(db *gorm.DB) *gorm.DB {
+	return db.Order("nama")
+}
+
+// notFound mengganti gorm.ErrRecordNotFound dengan ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
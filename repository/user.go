@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// UserRepository mengambil data user
+type UserRepository interface {
+	// FindByUsername mengambil user berdasarkan username
+	FindByUsername(ctx context.Context, username string) (models.User, error)
+}
+
+// userRepository mengambil user dari database lewat GORM
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository membuat UserRepository dari koneksi database utama
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	return user, notFound(err)
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	admin := router.Group("/admin")
+	{
+		admin.POST("/komik/import", auth.AuthMiddleware(1), h.Bulk.ImportKomik)
+		admin.GET("/komik/export", auth.AuthMiddleware(1), h.Bulk.ExportKomik)
+		admin.GET("/komik/low-stock", auth.AuthMiddleware(1), h.Inventory.GetLowStock)
+		admin.GET("/komik/:id/stock", auth.AuthMiddleware(1), h.Inventory.GetStockMovements)
+		admin.POST("/komik/:id/stock", auth.AuthMiddleware(1), h.Inventory.CreateStockMovement)
+		admin.POST("/inventory/reconcile", auth.AuthMiddleware(1), h.Inventory.ReconcileStock)
+
+		admin.GET("/webhooks", auth.AuthMiddleware(1), h.Webhook.GetWebhooks)
+		admin.POST("/webhooks", auth.AuthMiddleware(1), h.Webhook.CreateWebhook)
+		admin.GET("/webhooks/:id", auth.AuthMiddleware(1), h.Webhook.GetWebhookByID)
+		admin.PUT("/webhooks/:id", auth.AuthMiddleware(1), h.Webhook.UpdateWebhook)
+		admin.DELETE("/webhooks/:id", auth.AuthMiddleware(1), h.Webhook.DeleteWebhook)
+		admin.GET("/webhooks/:id/deliveries", auth.AuthMiddleware(1), h.Webhook.GetWebhookDeliveries)
+		admin.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", auth.AuthMiddleware(1), h.Webhook.RedeliverWebhook)
+
+		admin.GET("/audit-logs", auth.AuthMiddleware(1), h.Audit.GetAuditLogs)
+		admin.GET("/audit-logs/export", auth.AuthMiddleware(1), h.Audit.ExportAuditLogs)
+	}
+}
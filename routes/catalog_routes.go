@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCatalogRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	authors := router.Group("/authors")
+	{
+		authors.GET("/", auth.AuthMiddleware(1, 2), h.Catalog.GetAuthors)
+		authors.POST("/", auth.AuthMiddleware(1), h.Catalog.CreateAuthor)
+		authors.GET("/:id", auth.AuthMiddleware(1, 2), h.Catalog.GetAuthorByID)
+		authors.PUT("/:id", auth.AuthMiddleware(1), h.Catalog.UpdateAuthor)
+		authors.DELETE("/:id", auth.AuthMiddleware(1), h.Catalog.DeleteAuthor)
+	}
+
+	publishers := router.Group("/publishers")
+	{
+		publishers.GET("/", auth.AuthMiddleware(1, 2), h.Catalog.GetPublishers)
+		publishers.POST("/", auth.AuthMiddleware(1), h.Catalog.CreatePublisher)
+		publishers.GET("/:id", auth.AuthMiddleware(1, 2), h.Catalog.GetPublisherByID)
+		publishers.PUT("/:id", auth.AuthMiddleware(1), h.Catalog.UpdatePublisher)
+		publishers.DELETE("/:id", auth.AuthMiddleware(1), h.Catalog.DeletePublisher)
+	}
+
+	genres := router.Group("/genres")
+	{
+		genres.GET("/", auth.AuthMiddleware(1, 2), h.Catalog.GetGenres)
+		genres.POST("/", auth.AuthMiddleware(1), h.Catalog.CreateGenre)
+		genres.GET("/:id", auth.AuthMiddleware(1, 2), h.Catalog.GetGenreByID)
+		genres.PUT("/:id", auth.AuthMiddleware(1), h.Catalog.UpdateGenre)
+		genres.DELETE("/:id", auth.AuthMiddleware(1), h.Catalog.DeleteGenre)
+	}
+}
@@ -7,12 +7,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterCommentRoutes(router *gin.Engine) {
+func RegisterCommentRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
 	commentRoutes := router.Group("/comments")
 	{
-		commentRoutes.GET("/", middlewares.AuthMiddleware(1, 2), controllers.GetAllComments)
-		commentRoutes.POST("/", middlewares.AuthMiddleware(2), controllers.CreateComment)
-		commentRoutes.PUT(":id", middlewares.AuthMiddleware(2), controllers.UpdateComment)
-		commentRoutes.DELETE(":id", middlewares.AuthMiddleware(1, 2), controllers.DeleteComment)
+		commentRoutes.GET("/", auth.AuthMiddleware(1, 2), h.Comment.GetAllComments)
+		commentRoutes.POST("/", auth.AuthMiddleware(2), h.Comment.CreateComment)
+		commentRoutes.GET(":id", auth.AuthMiddleware(1, 2), h.Comment.GetCommentByID)
+		commentRoutes.PUT(":id", auth.AuthMiddleware(2), h.Comment.UpdateComment)
+		commentRoutes.PATCH(":id", auth.AuthMiddleware(2), h.Comment.PatchComment)
+		commentRoutes.DELETE(":id", auth.AuthMiddleware(1, 2), h.Comment.DeleteComment)
+		commentRoutes.GET(":id/history", auth.AuthMiddleware(1, 2), h.Comment.GetCommentHistory)
+		commentRoutes.POST(":id/reactions/:reaksi", auth.AuthMiddleware(1, 2), h.Comment.ToggleReaction)
 	}
 }
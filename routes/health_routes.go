@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHealthRoutes(router *gin.Engine, h *controllers.Handlers) {
+	router.GET("/healthz", h.Health.Healthz)
+	router.GET("/readyz", h.Health.Readyz)
+}
@@ -7,17 +7,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(r *gin.Engine) {
+func RegisterRoutes(r *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
 	// Rute login
-	r.POST("/login", controllers.Login)
+	r.POST("/login", h.Auth.Login)
 	// Rute CRUD komik
 	komik := r.Group("/komik")
 	{
-		komik.GET("/", middlewares.AuthMiddleware(1, 2), controllers.GetKomik)
-		komik.POST("/", middlewares.AuthMiddleware(1), controllers.CreateKomik)
-		komik.GET("/:id", middlewares.AuthMiddleware(1, 2), controllers.GetKomikByID)
-		komik.PUT("/:id", middlewares.AuthMiddleware(1), controllers.UpdateKomik)
-		komik.DELETE("/:id", middlewares.AuthMiddleware(1), controllers.DeleteKomik)
-		komik.GET("/updates", controllers.HandleWebSocket) // Rute WebSocket
+		komik.GET("/", auth.AuthMiddleware(1, 2), h.Komik.GetKomik)
+		komik.POST("/", auth.AuthMiddleware(1), h.Komik.CreateKomik)
+		komik.GET("/:id", auth.AuthMiddleware(1, 2), h.Komik.GetKomikByID)
+		komik.PUT("/:id", auth.AuthMiddleware(1), h.Komik.UpdateKomik)
+		komik.PATCH("/:id", auth.AuthMiddleware(1), h.Komik.PatchKomik)
+		komik.DELETE("/:id", auth.AuthMiddleware(1), h.Komik.DeleteKomik)
+		komik.POST("/:id/cover", auth.AuthMiddleware(1), h.Cover.UploadCover)
+		komik.GET("/:id/cover", h.Cover.GetCover) // Tanpa token agar dapat dipakai di tag img
+		komik.DELETE("/:id/cover", auth.AuthMiddleware(1), h.Cover.DeleteCover)
+		komik.GET("/:id/comments", auth.AuthMiddleware(1, 2), h.Comment.GetKomikComments)
+		komik.GET("/updates", auth.OptionalAuth(), h.WebSocket.HandleWebSocket) // Rute WebSocket, hanya data komik
 	}
+	// WebSocket dengan event {"type", "data"} untuk stok, stok rendah dan notifikasi
+	r.GET("/v2/komik/updates", auth.OptionalAuth(), h.WebSocket.HandleEvents)
+	// Data buku dari ISBN untuk mengisi form komik
+	r.GET("/isbn/:isbn", auth.AuthMiddleware(1), h.ISBN.LookupISBN)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterNotificationRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	notifications := router.Group("/notifications")
+	{
+		notifications.GET("/", auth.AuthMiddleware(1, 2), h.Notification.GetNotifications)
+		notifications.POST("/read-all", auth.AuthMiddleware(1, 2), h.Notification.MarkAllNotificationsRead)
+		notifications.POST("/:id/read", auth.AuthMiddleware(1, 2), h.Notification.MarkNotificationRead)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPricingRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	router.POST("/pricing/quote", auth.AuthMiddleware(1, 2), h.Pricing.GetQuote)
+
+	discounts := router.Group("/discounts")
+	{
+		discounts.GET("/", auth.AuthMiddleware(1), h.Pricing.GetDiscounts)
+		discounts.POST("/", auth.AuthMiddleware(1), h.Pricing.CreateDiscount)
+		discounts.GET("/:id", auth.AuthMiddleware(1), h.Pricing.GetDiscountByID)
+		discounts.PUT("/:id", auth.AuthMiddleware(1), h.Pricing.UpdateDiscount)
+		discounts.DELETE("/:id", auth.AuthMiddleware(1), h.Pricing.DeleteDiscount)
+	}
+}
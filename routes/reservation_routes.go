@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterReservationRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	reservations := router.Group("/reservations")
+	{
+		reservations.GET("/", auth.AuthMiddleware(1, 2), h.Reservation.GetReservations)
+		reservations.POST("/", auth.AuthMiddleware(1, 2), h.Reservation.CreateReservation)
+		reservations.POST("/confirm", auth.AuthMiddleware(1, 2), h.Reservation.ConfirmReservations)
+		reservations.DELETE("/:id", auth.AuthMiddleware(1, 2), h.Reservation.DeleteReservation)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSeriesRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	series := router.Group("/series")
+	{
+		series.GET("/", auth.AuthMiddleware(1, 2), h.Series.GetSeries)
+		series.POST("/", auth.AuthMiddleware(1), h.Series.CreateSeries)
+		series.GET("/:id", auth.AuthMiddleware(1, 2), h.Series.GetSeriesByID)
+		series.PUT("/:id", auth.AuthMiddleware(1), h.Series.UpdateSeries)
+		series.DELETE("/:id", auth.AuthMiddleware(1), h.Series.DeleteSeries)
+
+		// Volume dan chapter dalam series
+		series.POST("/:id/volumes", auth.AuthMiddleware(1), h.Series.CreateVolume)
+		series.GET("/:id/volumes/:nomor", auth.AuthMiddleware(1, 2), h.Series.GetVolume)
+		series.DELETE("/:id/volumes/:nomor", auth.AuthMiddleware(1), h.Series.DeleteVolume)
+		series.POST("/:id/volumes/:nomor/chapters", auth.AuthMiddleware(1), h.Series.CreateChapter)
+		series.DELETE("/:id/volumes/:nomor/chapters/:chapter", auth.AuthMiddleware(1), h.Series.DeleteChapter)
+	}
+}
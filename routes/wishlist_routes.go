@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWishlistRoutes(router *gin.Engine, h *controllers.Handlers, auth *middlewares.Auth) {
+	wishlist := router.Group("/wishlist")
+	{
+		wishlist.GET("/", auth.AuthMiddleware(1, 2), h.Wishlist.GetWishlist)
+		wishlist.POST("/", auth.AuthMiddleware(1, 2), h.Wishlist.AddWishlist)
+		wishlist.DELETE("/:komik_id", auth.AuthMiddleware(1, 2), h.Wishlist.RemoveWishlist)
+	}
+}
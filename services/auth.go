@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/repository"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ErrLoginGagal dikembalikan jika user tidak dapat ditemukan atau password salah
+var ErrLoginGagal = errors.New("username atau password salah")
+
+// tokenTTL adalah masa berlaku token hasil login
+const tokenTTL = 24 * time.Hour
+
+// AuthService memeriksa login user dan membuat token JWT
+type AuthService struct {
+	users     repository.UserRepository
+	secretKey []byte
+}
+
+// NewAuthService membuat AuthService yang menandatangani token dengan secretKey
+func NewAuthService(users repository.UserRepository, secretKey []byte) *AuthService {
+	return &AuthService{users: users, secretKey: secretKey}
+}
+
+// Login memeriksa username dan password lalu mengembalikan token JWT berisi user_id dan
+// role_id yang berlaku selama 24 jam
+func (s *AuthService) Login(ctx context.Context, username, password string) (string, error) {
+	user, err := s.users.FindByUsername(ctx, username)
+	if err != nil {
+		return "", ErrLoginGagal
+	}
+
+	// Bandingkan password plaintext secara langsung
+	if user.Password != password {
+		return "", ErrLoginGagal
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": user.ID,
+		"role_id": user.RoleID,
+		"exp":     time.Now().Add(tokenTTL).Unix(),
+	})
+	return token.SignedString(s.secretKey)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+
+	"backend/models"
+	"backend/repository"
+	"backend/validation"
+)
+
+// pesanKomentarTidakDitemukan adalah pesan error jika komentar yang diminta tidak ada
+const pesanKomentarTidakDitemukan = "Komentar tidak ditemukan"
+
+// CommentService berisi aturan akses dan pengelolaan komentar. Admin dapat melihat dan
+// menghapus semua komentar, sedangkan user hanya dapat mengelola komentarnya sendiri
+type CommentService struct {
+	comments repository.CommentRepository
+	komiks   repository.KomikRepository
+}
+
+// NewCommentService membuat CommentService. komiks dipakai untuk memastikan komik yang
+// dikomentari ada
+func NewCommentService(comments repository.CommentRepository, komiks repository.KomikRepository) *CommentService {
+	return &CommentService{comments: comments, komiks: komiks}
+}
+
+// List mengambil semua komentar untuk admin, atau komentar milik actor untuk user
+func (s *CommentService) List(ctx context.Context, actor repository.Actor) ([]models.Comment, error) {
+	var filter repository.CommentFilter
+	switch actor.RoleID {
+	case 1:
+		// Admin dapat melihat semua komentar
+	case 2:
+		// User hanya dapat melihat komentar miliknya sendiri
+		filter.UserID = actor.UserID
+	default:
+		return nil, forbidden("Role tidak diizinkan untuk melihat komentar")
+	}
+	return s.list(ctx, filter, actor)
+}
+
+// ListByKomik mengambil komentar pada sebuah komik dengan urutan repository.UrutanTerbaru
+// atau repository.UrutanTop
+func (s *CommentService) ListByKomik(ctx context.Context, komikID uint, urutan string, actor repository.Actor) ([]models.Comment, error) {
+	if _, err := s.komiks.Find(ctx, komikID); err != nil {
+		return nil, orNotFound(err, pesanKomikTidakDitemukan)
+	}
+	if urutan != repository.UrutanTerbaru && urutan != repository.UrutanTop {
+		return nil, invalid("Parameter sort tidak valid")
+	}
+	return s.list(ctx, repository.CommentFilter{KomikID: komikID, Urutan: urutan}, actor)
+}
+
+// list mengambil komentar sesuai filter beserta reaksinya untuk actor
+func (s *CommentService) list(ctx context.Context, filter repository.CommentFilter, actor repository.Actor) ([]models.Comment, error) {
+	comments, err := s.comments.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.comments.AttachReactions(ctx, comments, actor.UserID); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// Get mengambil satu komentar beserta reaksinya untuk actor
+func (s *CommentService) Get(ctx context.Context, id uint, actor repository.Actor) (models.Comment, error) {
+	comment, err := s.comments.Get(ctx, id)
+	if err != nil {
+		return comment, orNotFound(err, pesanKomentarTidakDitemukan)
+	}
+	comments := []models.Comment{comment}
+	if err := s.comments.AttachReactions(ctx, comments, actor.UserID); err != nil {
+		return comment, err
+	}
+	return comments[0], nil
+}
+
+// Create menyimpan komentar baru milik actor sebagai versi pertama. Hanya user yang dapat
+// berkomentar
+func (s *CommentService) Create(ctx context.Context, comment *models.Comment, actor repository.Actor) error {
+	if actor.RoleID != 2 {
+		return forbidden("Hanya user yang dapat membuat komentar")
+	}
+	if _, err := s.komiks.Find(ctx, comment.KomikID); err != nil {
+		return orNotFound(err, "Komik tidak ditemukan")
+	}
+
+	comment.ID = 0
+	comment.UserID = actor.UserID
+	comment.EditedAt = nil
+	comment.Version = 1
+	return s.comments.Create(ctx, comment)
+}
+
+// FindOwn mengambil komentar milik actor yang akan diedit
+func (s *CommentService) FindOwn(ctx context.Context, id uint, actor repository.Actor) (models.Comment, error) {
+	if actor.RoleID != 2 {
+		return models.Comment{}, forbidden("Hanya user yang dapat mengedit komentar")
+	}
+	comment, err := s.comments.Find(ctx, id)
+	if err != nil {
+		return comment, orNotFound(err, pesanKomentarTidakDitemukan)
+	}
+	if comment.UserID != actor.UserID {
+		return comment, forbidden("Tidak diizinkan mengedit komentar ini")
+	}
+	return comment, nil
+}
+
+// Update memvalidasi lalu menyimpan perubahan komentar. previous adalah isi komentar
+// sebelum diedit
+func (s *CommentService) Update(ctx context.Context, comment *models.Comment, previous string, actor repository.Actor) error {
+	if err := validation.Validate(comment); err != nil {
+		return err
+	}
+	return s.comments.Update(ctx, comment, previous, actor.UserID)
+}
+
+// History mengambil riwayat edit komentar. Hanya pemilik komentar dan admin yang dapat
+// melihatnya, dan hanya admin yang dapat melihat riwayat komentar yang sudah dihapus
+func (s *CommentService) History(ctx context.Context, id uint, actor repository.Actor) ([]models.CommentRevision, error) {
+	get := s.comments.Get
+	if actor.IsAdmin() {
+		get = s.comments.GetWithDeleted
+	}
+	comment, err := get(ctx, id)
+	if err != nil {
+		return nil, orNotFound(err, pesanKomentarTidakDitemukan)
+	}
+	if !actor.IsAdmin() && comment.UserID != actor.UserID {
+		return nil, forbidden("Tidak diizinkan melihat riwayat komentar ini")
+	}
+	return s.comments.History(ctx, comment.ID)
+}
+
+// FindDeletable mengambil komentar yang akan dihapus actor. User hanya dapat menghapus
+// komentarnya sendiri
+func (s *CommentService) FindDeletable(ctx context.Context, id uint, actor repository.Actor) (models.Comment, error) {
+	comment, err := s.comments.Find(ctx, id)
+	if err != nil {
+		return comment, orNotFound(err, pesanKomentarTidakDitemukan)
+	}
+	if actor.RoleID == 2 && comment.UserID != actor.UserID {
+		return comment, forbidden("Tidak diizinkan menghapus komentar ini")
+	}
+	return comment, nil
+}
+
+// Delete menghapus komentar. Penghapusan oleh admin dicatat di audit log, dan pemilik
+// komentar mendapat notifikasi beserta alasannya jika yang menghapus bukan dirinya sendiri
+func (s *CommentService) Delete(ctx context.Context, comment *models.Comment, alasan string, actor repository.Actor) error {
+	return s.comments.Delete(ctx, comment, repository.CommentDeletion{
+		Actor:  actor,
+		Audit:  actor.IsAdmin(),
+		Notify: comment.UserID != actor.UserID,
+		Alasan: alasan,
+	})
+}
+
+// ToggleReaction memberi reaksi actor pada komentar atau membatalkannya jika sudah ada,
+// lalu mengembalikan komentar dengan jumlah reaksi terbaru
+func (s *CommentService) ToggleReaction(ctx context.Context, id uint, reaksi string, actor repository.Actor) (models.Comment, error) {
+	if !models.ReaksiValid(reaksi) {
+		return models.Comment{}, invalid("Jenis reaksi tidak valid")
+	}
+	comment, err := s.comments.Find(ctx, id)
+	if err != nil {
+		return comment, orNotFound(err, pesanKomentarTidakDitemukan)
+	}
+	if err := s.comments.ToggleReaction(ctx, &comment, actor.UserID, reaksi); err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+
+	"backend/media"
+	"backend/models"
+	"backend/pricing"
+	"backend/repository"
+	"backend/validation"
+)
+
+// pesanKomikTidakDitemukan adalah pesan error jika komik yang diminta tidak ada
+const pesanKomikTidakDitemukan = "Data tidak ditemukan"
+
+// KomikService berisi aturan pengelolaan data komik
+type KomikService struct {
+	komiks  repository.KomikRepository
+	storage media.Storage
+}
+
+// NewKomikService membuat KomikService. storage dipakai untuk menghapus file cover komik
+// yang dihapus
+func NewKomikService(komiks repository.KomikRepository, storage media.Storage) *KomikService {
+	return &KomikService{komiks: komiks, storage: storage}
+}
+
+// List mengambil komik sesuai filter
+func (s *KomikService) List(ctx context.Context, filter repository.KomikFilter) ([]models.Komik, error) {
+	return s.komiks.List(ctx, filter)
+}
+
+// Get mengambil satu komik untuk ditampilkan
+func (s *KomikService) Get(ctx context.Context, id uint) (models.Komik, error) {
+	komik, err := s.komiks.Get(ctx, id)
+	return komik, orNotFound(err, pesanKomikTidakDitemukan)
+}
+
+// Find mengambil data komik yang akan diubah atau dihapus
+func (s *KomikService) Find(ctx context.Context, id uint) (models.Komik, error) {
+	komik, err := s.komiks.Find(ctx, id)
+	return komik, orNotFound(err, pesanKomikTidakDitemukan)
+}
+
+// Create menyimpan komik baru sebagai versi pertama. Volume diabaikan karena dikelola
+// lewat series
+func (s *KomikService) Create(ctx context.Context, komik *models.Komik, actor repository.Actor) error {
+	komik.ID = 0
+	komik.Version = 1
+	komik.Volume = nil
+	komik.MataUang = pricing.MataUang(komik.MataUang)
+	komik.NormalizeISBN()
+	return s.komiks.Create(ctx, komik, actor)
+}
+
+// Update memvalidasi lalu menyimpan perubahan komik. previous adalah data komik sebelum
+// diubah, nil jika seluruh data diganti
+func (s *KomikService) Update(ctx context.Context, komik *models.Komik, previous *models.Komik, actor repository.Actor) error {
+	komik.MataUang = pricing.MataUang(komik.MataUang)
+	komik.NormalizeISBN()
+	if err := validation.Validate(komik); err != nil {
+		return err
+	}
+	return s.komiks.Update(ctx, komik, previous, actor)
+}
+
+// Delete menghapus komik lalu file cover-nya
+func (s *KomikService) Delete(ctx context.Context, komik *models.Komik, actor repository.Actor) error {
+	if err := s.komiks.Delete(ctx, komik, actor); err != nil {
+		return err
+	}
+	if komik.Cover != "" {
+		media.DeleteCover(s.storage, komik.Cover)
+	}
+	return nil
+}
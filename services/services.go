@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+
+	"backend/repository"
+)
+
+// Jenis error dari service. Handler memilih status response berdasarkan jenisnya
+var (
+	ErrNotFound  = errors.New("data tidak ditemukan")
+	ErrForbidden = errors.New("tidak diizinkan")
+	ErrInvalid   = errors.New("data tidak valid")
+)
+
+// Error adalah error service dengan pesan yang dapat ditampilkan ke user
+type Error struct {
+	Kind  error // ErrNotFound, ErrForbidden atau ErrInvalid
+	Pesan string
+}
+
+func (e *Error) Error() string {
+	return e.Pesan
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+func notFound(pesan string) error {
+	return &Error{Kind: ErrNotFound, Pesan: pesan}
+}
+
+func forbidden(pesan string) error {
+	return &Error{Kind: ErrForbidden, Pesan: pesan}
+}
+
+func invalid(pesan string) error {
+	return &Error{Kind: ErrInvalid, Pesan: pesan}
+}
+
+// orNotFound mengganti repository.ErrNotFound dengan error ErrNotFound berpesan pesan
+func orNotFound(err error, pesan string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return notFound(pesan)
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey adalah kunci span query yang disimpan di statement GORM
+const spanKey = "tracing:span"
+
+// GormPlugin membuat span untuk setiap query GORM yang dijalankan dengan context yang
+// membawa span, misalnya lewat db.WithContext di handler request. Query tanpa span induk,
+// seperti polling pekerjaan latar belakang, tidak dicatat. Dipasang dengan db.Use
+type GormPlugin struct{}
+
+// Name mengembalikan nama plugin
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize mendaftarkan callback sebelum dan sesudah setiap jenis operasi GORM
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("tracing:before_create", before("create")),
+		cb.Create().After("*").Register("tracing:after_create", after),
+		cb.Query().Before("*").Register("tracing:before_query", before("query")),
+		cb.Query().After("*").Register("tracing:after_query", after),
+		cb.Update().Before("*").Register("tracing:before_update", before("update")),
+		cb.Update().After("*").Register("tracing:after_update", after),
+		cb.Delete().Before("*").Register("tracing:before_delete", before("delete")),
+		cb.Delete().After("*").Register("tracing:after_delete", after),
+		cb.Row().Before("*").Register("tracing:before_row", before("row")),
+		cb.Row().After("*").Register("tracing:after_row", after),
+		cb.Raw().Before("*").Register("tracing:before_raw", before("raw")),
+		cb.Raw().After("*").Register("tracing:after_raw", after),
+	)
+}
+
+func before(operasi string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		nama := "db." + operasi
+		if tabel := db.Statement.Table; tabel != "" {
+			nama += " " + tabel
+		}
+		_, span := Tracer().Start(ctx, nama,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemKey.String(db.Dialector.Name()),
+				semconv.DBOperationName(operasi),
+			),
+		)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	// SQL dicatat dengan placeholder, nilai parameternya tidak ikut dikirim
+	if sql := strings.TrimSpace(db.Statement.SQL.String()); sql != "" {
+		span.SetAttributes(semconv.DBQueryText(sql))
+	}
+	if tabel := db.Statement.Table; tabel != "" {
+		span.SetAttributes(semconv.DBCollectionName(tabel))
+	}
+	if db.Statement.RowsAffected >= 0 {
+		span.SetAttributes(attribute.Int64("db.rows_affected", db.Statement.RowsAffected))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// name adalah nama instrumentasi untuk semua span yang dibuat aplikasi
+const name = "backend"
+
+// Tracer mengembalikan tracer aplikasi dari TracerProvider global. Sebelum Setup dipanggil
+// span yang dibuat tidak dicatat
+func Tracer() trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Setup menjadikan TracerProvider yang mengirim span ke exporter sebagai provider global,
+// dengan sampling sesuai ratio (0 sampai 1) untuk trace baru. Trace dari request yang sudah
+// memiliki trace context mengikuti keputusan sampling pemanggilnya. Jika exporter nil span
+// tidak dikirim ke mana pun, tetapi trace context tetap diteruskan. Fungsi yang dikembalikan
+// mengirim sisa span lalu menghentikan provider
+func Setup(exporter sdktrace.SpanExporter, service string, ratio float64) func(context.Context) error {
+	// Trace context W3C (traceparent, tracestate) dan baggage dibaca dari request masuk
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if exporter == nil {
+		return func(context.Context) error { return nil }
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(service))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown
+}
+
+// Inject mengembalikan trace context dari ctx dalam format header traceparent W3C, kosong
+// jika ctx tidak membawa span
+func Inject(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// Extract menambahkan trace context dari traceparent hasil Inject ke ctx sebagai parent
+// span berikutnya. ctx dikembalikan apa adanya jika traceparent kosong atau tidak valid
+func Extract(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceparent})
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	nonstandard "github.com/go-playground/validator/v10/non-standard/validators"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// TahunTerbitMinimal adalah tahun terbit paling awal yang diterima
+const TahunTerbitMinimal = 1900
+
+var (
+	universal *ut.UniversalTranslator
+	once      sync.Once
+)
+
+// pesanCustom berisi terjemahan untuk aturan validasi buatan sendiri
+var pesanCustom = map[string]map[string]string{
+	"id": {
+		"notblank":     "{0} tidak boleh kosong",
+		"tahun_terbit": "{0} harus antara 1900 dan tahun depan",
+		"nilai_diskon": "{0} untuk diskon persen maksimal 100",
+		"iso4217":      "{0} harus berupa kode mata uang ISO 4217, misalnya IDR",
+		"http_url":     "{0} harus berupa URL http atau https",
+		// Aturan bawaan yang belum memiliki terjemahan bahasa Indonesia
+		"required_without_all": "{0} wajib diisi jika field pasangannya tidak diisi",
+		"excluded_with":        "{0} tidak boleh diisi bersamaan dengan field pasangannya",
+	},
+	"en": {
+		"notblank":     "{0} must not be blank",
+		"tahun_terbit": "{0} must be between 1900 and next year",
+		"nilai_diskon": "{0} of a percentage discount must be at most 100",
+		"iso4217":      "{0} must be an ISO 4217 currency code such as IDR",
+		"http_url":     "{0} must be an http or https URL",
+	},
+}
+
+// Register mendaftarkan aturan validasi dan terjemahan pesan ke validator milik Gin.
+// Aman dipanggil lebih dari sekali
+func Register() {
+	once.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			panic("validation: validator Gin bukan go-playground/validator")
+		}
+
+		// Gunakan nama field pada tag json agar pesan sesuai dengan body request
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+
+		mustRegister(v.RegisterValidation("notblank", nonstandard.NotBlank))
+		mustRegister(v.RegisterValidation("tahun_terbit", validTahunTerbit))
+		mustRegister(v.RegisterValidation("nilai_diskon", validNilaiDiskon))
+
+		indonesia := id.New()
+		universal = ut.New(indonesia, indonesia, en.New())
+
+		transID, _ := universal.GetTranslator("id")
+		mustRegister(id_translations.RegisterDefaultTranslations(v, transID))
+		transEN, _ := universal.GetTranslator("en")
+		mustRegister(en_translations.RegisterDefaultTranslations(v, transEN))
+
+		for locale, messages := range pesanCustom {
+			trans, _ := universal.GetTranslator(locale)
+			for tag, message := range messages {
+				mustRegister(v.RegisterTranslation(tag, trans, addTranslation(tag, message), translate))
+			}
+		}
+	})
+}
+
+// validTahunTerbit memastikan tahun terbit berada di antara TahunTerbitMinimal dan tahun depan
+func validTahunTerbit(fl validator.FieldLevel) bool {
+	tahun := fl.Field().Int()
+	return tahun >= TahunTerbitMinimal && tahun <= int64(time.Now().Year()+1)
+}
+
+// validNilaiDiskon memastikan nilai diskon berjenis persen tidak lebih dari 100.
+// Jenis diskon dibaca dari field Jenis pada struct yang sama
+func validNilaiDiskon(fl validator.FieldLevel) bool {
+	jenis := reflect.Indirect(fl.Parent()).FieldByName("Jenis")
+	return !jenis.IsValid() || jenis.String() != "persen" || fl.Field().Int() <= 100
+}
+
+func addTranslation(tag, message string) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, message, true)
+	}
+}
+
+func translate(trans ut.Translator, fe validator.FieldError) string {
+	message, err := trans.T(fe.Tag(), fe.Field())
+	if err != nil {
+		return fe.Error()
+	}
+	return message
+}
+
+func mustRegister(err error) {
+	if err != nil {
+		panic("validation: " + err.Error())
+	}
+}
+
+// Validate menjalankan aturan validasi pada struct, misalnya setelah data diubah lewat PATCH
+func Validate(value interface{}) error {
+	return binding.Validator.ValidateStruct(value)
+}
+
+// Messages menerjemahkan error validasi menjadi pesan per field sesuai bahasa pada
+// header Accept-Language. Nilai ok bernilai false jika err bukan error validasi
+func Messages(err error, acceptLanguage string) (messages map[string]string, ok bool) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, false
+	}
+
+	Register()
+	trans, _ := universal.FindTranslator(parseAcceptLanguage(acceptLanguage)...)
+
+	messages = make(map[string]string, len(validationErrors))
+	for _, fe := range validationErrors {
+		messages[fe.Field()] = fe.Translate(trans)
+	}
+	return messages, true
+}
+
+// parseAcceptLanguage mengambil daftar kode bahasa dari header Accept-Language
+func parseAcceptLanguage(header string) []string {
+	var locales []string
+	for _, part := range strings.Split(header, ",") {
+		locale := strings.TrimSpace(strings.Split(part, ";")[0])
+		if locale == "" {
+			continue
+		}
+		locales = append(locales, locale, strings.Split(locale, "-")[0])
+	}
+	return locales
+}
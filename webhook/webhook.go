@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// Header yang dikirim bersama setiap pengiriman webhook
+const (
+	HeaderEvent     = "X-Komik-Event"
+	HeaderDelivery  = "X-Komik-Delivery"
+	HeaderTimestamp = "X-Komik-Timestamp" // Waktu pengiriman dalam detik Unix
+	HeaderSignature = "X-Komik-Signature" // "sha256=" diikuti HMAC-SHA256 hex dari "<timestamp>.<body>" dengan secret webhook
+)
+
+const (
+	backoffAwal     = 30 * time.Second
+	backoffMaksimal = 6 * time.Hour
+	maxRespons      = 1000 // Panjang maksimal body respons yang disimpan di log pengiriman
+)
+
+// Payload adalah body JSON yang dikirim ke webhook
+type Payload struct {
+	Event string      `json:"event"`
+	Waktu time.Time   `json:"waktu"`
+	Data  interface{} `json:"data"`
+}
+
+// Enqueue membuat pengiriman untuk setiap webhook aktif yang berlangganan event yang terjadi
+// pada waktu tertentu. Dipanggil oleh relay outbox; pengiriman dilakukan di latar belakang
+// oleh dispatcher
+func Enqueue(tx *gorm.DB, event string, waktu time.Time, data interface{}) error {
+	var webhooks []models.Webhook
+	if err := tx.Where("aktif = ?", true).Order("id").Find(&webhooks).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var body []byte
+	var deliveries []models.WebhookDelivery
+	for _, w := range webhooks {
+		if !w.Subscribed(event) {
+			continue
+		}
+		if body == nil {
+			var err error
+			if body, err = json.Marshal(Payload{Event: event, Waktu: waktu, Data: data}); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:      w.ID,
+			Event:          event,
+			Payload:        body,
+			Status:         models.PengirimanMenunggu,
+			BerikutnyaPada: &now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return tx.Create(&deliveries).Error
+}
+
+// Redeliver membuat salinan pengiriman dengan payload yang sama untuk segera dikirim ulang.
+// Pengiriman asal tetap tersimpan di log
+func Redeliver(tx *gorm.DB, delivery models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	now := time.Now()
+	redelivery := models.WebhookDelivery{
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		Status:         models.PengirimanMenunggu,
+		BerikutnyaPada: &now,
+		RedeliveryOf:   &delivery.ID,
+	}
+	if err := tx.Create(&redelivery).Error; err != nil {
+		return nil, err
+	}
+	return &redelivery, nil
+}
+
+// Sign menghitung tanda tangan body yang dikirim pada timestamp (detik Unix) dengan secret
+// webhook dalam format header HeaderSignature. Timestamp ikut ditandatangani agar penerima
+// dapat menolak pengiriman lama yang diputar ulang
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff mengembalikan jeda sebelum percobaan berikutnya setelah percobaan ke-n gagal:
+// 30 detik, 1 menit, 2 menit dan seterusnya berlipat dua sampai maksimal 6 jam
+func Backoff(percobaan int) time.Duration {
+	jeda := backoffAwal
+	for i := 1; i < percobaan && jeda < backoffMaksimal; i++ {
+		jeda *= 2
+	}
+	if jeda > backoffMaksimal {
+		jeda = backoffMaksimal
+	}
+	return jeda
+}
+
+// Result adalah respons dari penerima webhook
+type Result struct {
+	StatusCode int
+	Respons    string
+}
+
+// Sender mengirim pengiriman webhook lewat HTTP POST
+type Sender struct {
+	Client *http.Client
+}
+
+// NewSender membuat sender dengan batas waktu setiap pengiriman
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{Client: &http.Client{Timeout: timeout}}
+}
+
+// Send mengirim payload pengiriman ke URL webhook. Error dikembalikan jika request gagal
+// atau penerima merespons dengan status selain 2xx; Result tetap diisi jika ada respons
+func (s *Sender) Send(ctx context.Context, w models.Webhook, delivery models.WebhookDelivery) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Komik-Webhook/1.0")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	timestamp := time.Now().Unix()
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(w.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("gagal mengirim webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRespons))
+	result := Result{StatusCode: resp.StatusCode, Respons: string(body)}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("webhook merespons status %d", resp.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,304 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"backend/metrics"
+	"backend/tracing"
+
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	writeWait      = 10 * time.Second    // Batas waktu menulis satu pesan ke klien
+	pongWait       = 60 * time.Second    // Klien dianggap terputus jika tidak membalas ping selama ini
+	pingPeriod     = (pongWait * 9) / 10 // Jarak pengiriman ping, harus lebih kecil dari pongWait
+	maxMessageSize = 4096                // Ukuran maksimal pesan dari klien
+	sendBuffer     = 64                  // Jumlah pesan yang dapat mengantre untuk setiap klien
+)
+
+// Event adalah pesan yang dikirim server ke klien WebSocket
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// Format menentukan bentuk pesan yang dikirim ke klien
+type Format int
+
+const (
+	// FormatEvent mengirim semua event sebagai {"type", "data"}
+	FormatEvent Format = iota
+	// FormatLegacy hanya mengirim event stock_updated berupa data komiknya saja, bentuk pesan
+	// /komik/updates sebelum event memiliki jenis. Event lain tidak dikirim
+	FormatLegacy
+)
+
+// MessageHandler memproses satu pesan dari klien milik userID (0 jika tanpa token). Error
+// yang dikembalikan berarti pesan ditolak dan pesannya dikirim ke klien
+type MessageHandler func(ctx context.Context, userID uint, message []byte) error
+
+// ErrHubClosed dikembalikan jika klien terhubung ke hub yang sudah ditutup
+var ErrHubClosed = errors.New("hub WebSocket sudah ditutup")
+
+// Hub menyimpan klien WebSocket yang terhubung dan mengirim event ke semuanya.
+// Setiap klien memiliki antrean pesan sendiri sehingga klien yang lambat tidak
+// menahan pengiriman ke klien lain; klien yang antreannya penuh diputus
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+	closed  bool
+	pumps   sync.WaitGroup   // writePump yang masih berjalan
+	metrics *metrics.Metrics // Jumlah koneksi dan pesan yang dibuang
+}
+
+type client struct {
+	ctx      context.Context // Membawa ID request saat koneksi dibuka, dipakai untuk log
+	conn     *websocket.Conn
+	userID   uint // 0 jika klien terhubung tanpa token
+	format   Format
+	send     chan []byte
+	closeMsg []byte        // Close frame yang dikirim setelah antrean ditutup
+	done     chan struct{} // Ditutup saat pembacaan pesan dari klien berhenti
+}
+
+// NewHub membuat hub tanpa klien yang mencatat jumlah koneksi dan pesan yang dibuang ke m
+func NewHub(m *metrics.Metrics) *Hub {
+	return &Hub{clients: make(map[*client]struct{}), metrics: m}
+}
+
+// Broadcast mengirim event ke semua klien yang terhubung. ctx dipakai sebagai induk span
+// pengiriman
+func (h *Hub) Broadcast(ctx context.Context, event Event) {
+	h.send(ctx, event, func(*client) bool { return true })
+}
+
+// SendToUser mengirim event ke semua koneksi milik user tertentu
+func (h *Hub) SendToUser(ctx context.Context, userID uint, event Event) {
+	if userID == 0 {
+		return
+	}
+	h.send(ctx, event, func(c *client) bool { return c.userID == userID })
+}
+
+// send mengirim event ke klien yang memenuhi filter. Span pengiriman mencatat jumlah klien
+// yang menerima dan yang diputus karena antreannya penuh
+func (h *Hub) send(ctx context.Context, event Event, filter func(*client) bool) {
+	ctx, span := tracing.Tracer().Start(ctx, "websocket.send "+event.Type,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("websocket.event", event.Type)),
+	)
+	defer span.End()
+
+	message, err := json.Marshal(event)
+	var legacy []byte // nil jika event tidak dikirim ke klien FormatLegacy
+	if err == nil && event.Type == EventStockUpdated {
+		legacy, err = json.Marshal(event.Data)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal membuat pesan WebSocket", "type", event.Type, "error", err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	var terkirim, diputus int
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		if !filter(c) {
+			continue
+		}
+		pesan := message
+		if c.format == FormatLegacy {
+			if legacy == nil {
+				continue
+			}
+			pesan = legacy
+		}
+		select {
+		case c.send <- pesan:
+			terkirim++
+		default:
+			slog.WarnContext(c.ctx, "Antrean pesan klien WebSocket penuh, koneksi diputus", "user_id", c.userID)
+			h.metrics.WebsocketDropped.Inc()
+			h.remove(c, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "Antrean pesan penuh"))
+			diputus++
+		}
+	}
+	span.SetAttributes(attribute.Int("websocket.terkirim", terkirim), attribute.Int("websocket.diputus", diputus))
+}
+
+// Serve mendaftarkan koneksi milik userID (0 jika tanpa token) ke hub dengan bentuk pesan
+// format dan membaca pesan dari klien sampai koneksi terputus. Setiap pesan diteruskan ke
+// onMessage secara berurutan bersama ctx, sehingga log pemrosesan pesan menyertakan ID
+// request koneksinya. Setiap pesan dicatat sebagai trace baru yang ditautkan ke span request
+// upgrade koneksinya, karena koneksi dapat terbuka jauh lebih lama dari satu trace. Jika
+// onMessage mengembalikan error, pesan dianggap ditolak dan klien diberi tahu (lihat reject).
+// Jika hub sudah ditutup, koneksi langsung ditutup dengan close frame dan ErrHubClosed
+// dikembalikan
+func (h *Hub) Serve(ctx context.Context, conn *websocket.Conn, userID uint, format Format, onMessage MessageHandler) error {
+	c := &client{ctx: ctx, conn: conn, userID: userID, format: format, send: make(chan []byte, sendBuffer), done: make(chan struct{})}
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		conn.WriteControl(websocket.CloseMessage, closeGoingAway, time.Now().Add(writeWait))
+		conn.Close()
+		return ErrHubClosed
+	}
+	h.clients[c] = struct{}{}
+	h.pumps.Add(1)
+	h.mu.Unlock()
+	h.metrics.WebsocketConnections.Inc()
+
+	go func() {
+		defer h.pumps.Done()
+		c.writePump()
+	}()
+	defer func() {
+		close(c.done)
+		h.mu.Lock()
+		h.remove(c, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		h.mu.Unlock()
+	}()
+
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.WarnContext(ctx, "Gagal membaca pesan WebSocket", "user_id", userID, "error", err)
+			}
+			return nil
+		}
+		if err := h.handle(ctx, userID, message, onMessage); err != nil {
+			h.reject(c, err)
+		}
+	}
+}
+
+// handle menjalankan onMessage untuk satu pesan di dalam span-nya sendiri
+func (h *Hub) handle(ctx context.Context, userID uint, message []byte, onMessage MessageHandler) error {
+	ctx, span := tracing.Tracer().Start(ctx, "websocket.message",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.Int("websocket.ukuran", len(message))),
+	)
+	defer span.End()
+	if userID != 0 {
+		span.SetAttributes(attribute.Int64("user_id", int64(userID)))
+	}
+	err := onMessage(ctx, userID, message)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// reject memberi tahu klien bahwa pesannya ditolak karena err. Klien FormatEvent menerima
+// event error berisi pesan err, sedangkan klien FormatLegacy yang tidak mengenal jenis event
+// diputus dengan close frame 1008 (policy violation)
+func (h *Hub) reject(c *client, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+	if c.format == FormatLegacy {
+		h.remove(c, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()))
+		return
+	}
+
+	message, _ := json.Marshal(Event{Type: EventError, Data: err.Error()})
+	select {
+	case c.send <- message:
+	default:
+		h.metrics.WebsocketDropped.Inc()
+		h.remove(c, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "Antrean pesan penuh"))
+	}
+}
+
+// closeGoingAway adalah close frame untuk klien saat server berhenti
+var closeGoingAway = websocket.FormatCloseMessage(websocket.CloseGoingAway, "Server berhenti")
+
+// Close menutup hub: klien baru ditolak dan semua klien yang terhubung dikirimi close frame
+// "going away" setelah pesan yang sudah mengantre terkirim. Close menunggu sampai semua
+// koneksi tertutup atau ctx berakhir
+func (h *Hub) Close(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	for c := range h.clients {
+		h.remove(c, closeGoingAway)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.pumps.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// remove memutus klien dari hub dengan close frame closeMsg. Pemanggil harus memegang h.mu
+func (h *Hub) remove(c *client, closeMsg []byte) {
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		c.closeMsg = closeMsg
+		close(c.send)
+		h.metrics.WebsocketConnections.Dec()
+	}
+}
+
+// writePump mengirim pesan dari antrean dan ping berkala ke klien. Jika antrean ditutup oleh
+// hub, close frame dikirim lalu koneksi ditutup setelah klien membalasnya atau writeWait
+// berlalu. Koneksi juga ditutup jika pengiriman gagal
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				if c.conn.WriteMessage(websocket.CloseMessage, c.closeMsg) == nil {
+					select {
+					case <-c.done:
+					case <-time.After(writeWait):
+					}
+				}
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				slog.WarnContext(c.ctx, "Gagal mengirim pesan WebSocket", "user_id", c.userID, "error", err)
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -1,99 +1,46 @@
 package websocket
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"net/http"
-	"sync"
-
-	"backend/config"
-	"backend/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
 
+// Jenis event yang dikirim ke klien
+const (
+	EventStockUpdated = "stock_updated" // Data: komik dengan stok terbaru
+	EventStockLow     = "stock_low"     // Data: alerts.StockLow
+	EventNotification = "notification"  // Data: models.Notification, hanya untuk pemiliknya
+	EventError        = "error"         // Data: alasan pesan klien ditolak, hanya untuk pengirimnya
+)
+
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
 		return true
 	},
 }
 
-var clients = make(map[*websocket.Conn]bool)
-var broadcast = make(chan KomikUpdate)
-var mu sync.Mutex // Untuk memastikan thread-safe
-
-// Struktur untuk pesan WebSocket
-type KomikUpdate struct {
-	KomikID uint   `json:"komik_id"`
-	Action  string `json:"action"` // "tambah" atau "kurang"
-	UserID  uint   `json:"user_id"`
-}
-
-// HandleWebSocket untuk menangani koneksi WebSocket
-func HandleWebSocket(c *gin.Context) {
+// Upgrade meng-upgrade request menjadi koneksi WebSocket dan mendaftarkannya ke hub dengan
+// bentuk pesan format. Jika request membawa token (user_id diisi middleware), event untuk
+// user tersebut ikut dikirim
+func (h *Hub) Upgrade(c *gin.Context, format Format, onMessage MessageHandler) {
+	// Context koneksi tetap membawa ID request dan span upgrade tetapi tidak ikut berakhir
+	// bersama request upgrade
+	ctx := context.WithoutCancel(c.Request.Context())
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		log.Println("Gagal upgrade ke WebSocket:", err)
+		slog.WarnContext(ctx, "Gagal upgrade ke WebSocket", "error", err)
 		return
 	}
-	defer conn.Close()
-
-	mu.Lock()
-	clients[conn] = true
-	mu.Unlock()
-
-	log.Println("Client terhubung")
-
-	for {
-		var update KomikUpdate
-		err := conn.ReadJSON(&update)
-		if err != nil {
-			log.Println("Error membaca pesan:", err)
-			mu.Lock()
-			delete(clients, conn)
-			mu.Unlock()
-			break
-		}
-
-		// Kirim data ke channel broadcast
-		broadcast <- update
-	}
-}
 
-// HandleMessages untuk memproses update stok komik
-func HandleMessages() {
-	for {
-		update := <-broadcast
-
-		var komik models.Komik
-		if err := config.DB.First(&komik, update.KomikID).Error; err != nil {
-			log.Println("Komik tidak ditemukan:", err)
-			continue
-		}
-
-		mu.Lock()
-		if update.Action == "tambah" {
-			// Tambahkan stok jika stok tidak melebihi batas awal stok
-			komik.Stok++
-		} else if update.Action == "kurang" {
-			// Kurangi stok jika stok lebih besar dari 0
-			if komik.Stok > 0 {
-				komik.Stok--
-			}
-		}
-		config.DB.Save(&komik)
-		mu.Unlock()
-
-		// Broadcast data stok terbaru ke semua klien
-		for client := range clients {
-			err := client.WriteJSON(komik)
-			if err != nil {
-				log.Println("Error mengirim pesan:", err)
-				client.Close()
-				mu.Lock()
-				delete(clients, client)
-				mu.Unlock()
-			}
-		}
+	userID := c.GetUint("user_id")
+	slog.InfoContext(ctx, "Klien WebSocket terhubung", "user_id", userID)
+	if err := h.Serve(ctx, conn, userID, format, onMessage); err != nil {
+		slog.InfoContext(ctx, "Klien WebSocket ditolak", "user_id", userID, "error", err)
+		return
 	}
+	slog.InfoContext(ctx, "Klien WebSocket terputus", "user_id", userID)
 }